@@ -0,0 +1,331 @@
+package goverify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Bind decodes the JSON body of r into dst, then runs Transform and Validate.
+// The body is limited by WithMaxBodySize (1 MiB by default) and unknown keys
+// can be rejected with WithDisallowUnknownFields. Decoding problems are
+// returned as *Err: JSON type mismatches become field violations, while
+// malformed, empty or oversized bodies carry the status written by WriteError.
+//
+// Example:
+//
+//	func createUser(w http.ResponseWriter, r *http.Request) {
+//	    var req CreateUserRequest
+//	    if err := Bind(r, &req, WithDisallowUnknownFields()); err != nil {
+//	        WriteError(w, err)
+//	        return
+//	    }
+//	    // req is decoded, transformed and valid
+//	}
+func Bind(r *http.Request, dst interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	if err := decodeJSONBody(r, dst, o); err != nil {
+		return err
+	}
+	if err := Transform(dst, opts...); err != nil {
+		return err
+	}
+	if _, err := Validate(dst, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// BindJSON is the generic form of Bind, returning the decoded value.
+//
+// Example:
+//
+//	req, err := BindJSON[CreateUserRequest](r)
+//	if err != nil {
+//	    WriteError(w, err)
+//	    return
+//	}
+func BindJSON[T any](r *http.Request, opts ...Option) (T, error) {
+	var dst T
+	err := Bind(r, &dst, opts...)
+	return dst, err
+}
+
+// WriteError writes err as an application/json response (see ToJSONErr),
+// whatever encoding the client accepts; WriteErrorFor negotiates it instead.
+// An *Err is written with the status chosen by the function that produced
+// it, or 400 Bad Request; any other error is written as 500 Internal Server
+// Error without exposing its message.
+//
+// Example:
+//
+//	if err := Bind(r, &req); err != nil {
+//	    WriteError(w, err)
+//	    return
+//	}
+func WriteError(w http.ResponseWriter, err error) {
+	if err == nil {
+		return
+	}
+
+	e, status := errStatus(err)
+	if e == nil {
+		e = &Err{Msg: "internal server error"}
+	}
+
+	w.Header().Set("Content-Type", ContentTypeJSON)
+	w.WriteHeader(status)
+	w.Write(ToJSONErr(e))
+}
+
+// WriteErrorFor writes err like WriteError, with the encoding chosen from
+// the Accept header of r: application/problem+json (see ToProblemJSON) or
+// application/vnd.api+json (see ToJSONAPIErr) when the client prefers one of
+// them, and application/json otherwise. Handler writes its errors with it.
+//
+// Example:
+//
+//	if err := Bind(r, &req); err != nil {
+//	    WriteErrorFor(w, r, err) // Accept: application/problem+json
+//	    return
+//	}
+func WriteErrorFor(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		return
+	}
+
+	_, status := errStatus(err)
+	switch contentType := errorContentType(r.Header.Get("Accept")); contentType {
+	case ContentTypeProblemJSON:
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		w.Write(ToProblemJSON(err))
+	case ContentTypeJSONAPI:
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		w.Write(ToJSONAPIErr(err))
+	default:
+		WriteError(w, err)
+	}
+}
+
+// errorContentType returns the error media type with the highest quality in
+// an Accept header, or ContentTypeJSON when it lists none of them.
+func errorContentType(accept string) string {
+	best, bestQ := ContentTypeJSON, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		switch mediaType {
+		case ContentTypeJSON, ContentTypeProblemJSON, ContentTypeJSONAPI:
+			if q > bestQ {
+				best, bestQ = mediaType, q
+			}
+		}
+	}
+	return best
+}
+
+// errStatus returns the *Err wrapped in err and the HTTP status it is written
+// with: the status chosen by the function that produced it, or 400 Bad
+// Request. Errors that do not wrap an *Err return nil and 500 Internal Server
+// Error.
+func errStatus(err error) (*Err, int) {
+	var e *Err
+	if !errors.As(err, &e) {
+		return nil, http.StatusInternalServerError
+	}
+	if e.status != 0 {
+		return e, e.status
+	}
+	return e, http.StatusBadRequest
+}
+
+func decodeJSONBody(r *http.Request, dst interface{}, o *options) error {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mediaType != ContentTypeJSON && !strings.HasSuffix(mediaType, "+json")) {
+			return newStatusErr(http.StatusUnsupportedMediaType, "content type must be application/json", nil, o.locale)
+		}
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return newStatusErr(http.StatusBadRequest, "request body is empty", nil, o.locale)
+	}
+
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, o.maxBodySize))
+	if o.disallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(dst); err != nil {
+		return jsonDecodeErr(err, dst, o.locale)
+	}
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return jsonDecodeErr(err, dst, o.locale)
+		}
+		return newStatusErr(http.StatusBadRequest, "request body must contain a single JSON value", nil, o.locale)
+	}
+	return nil
+}
+
+// jsonDecodeErr converts an encoding/json error into an *Err.
+func jsonDecodeErr(err error, dst interface{}, locale string) error {
+	var (
+		maxErr     *http.MaxBytesError
+		syntaxErr  *json.SyntaxError
+		typeErr    *json.UnmarshalTypeError
+		invalidErr *json.InvalidUnmarshalError
+	)
+
+	switch {
+	case errors.As(err, &invalidErr):
+		return err
+	case errors.As(err, &maxErr):
+		return newStatusErr(http.StatusRequestEntityTooLarge, "request body too large", nil, locale)
+	case errors.Is(err, io.EOF):
+		return newStatusErr(http.StatusBadRequest, "request body is empty", nil, locale)
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return newStatusErr(http.StatusBadRequest, "malformed JSON", nil, locale)
+	case errors.As(err, &typeErr):
+		path, pointer := resolveJSONPath(reflect.TypeOf(dst), splitJSONField(typeErr.Field))
+		vl := bindViolation(path, pointer, ruleSpec{name: "type", param: jsonTypeName(typeErr.Type)}, "must be of type {param}")
+		return newStatusErr(http.StatusBadRequest, "validation failed", []Violation{vl}, locale)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		name, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field "))
+		vl := bindViolation(name, "/"+escapePointer(name), ruleSpec{name: "unknown"}, "unknown field")
+		return newStatusErr(http.StatusBadRequest, "validation failed", []Violation{vl}, locale)
+	}
+	return newStatusErr(http.StatusBadRequest, "malformed JSON", nil, locale)
+}
+
+// newStatusErr builds an Err carrying the HTTP status used by WriteError.
+func newStatusErr(status int, msgID string, violations []Violation, locale string) *Err {
+	e := newViolationErr(msgID, violations, locale)
+	if len(violations) == 0 {
+		e.Fields = nil
+	}
+	e.status = status
+	return e
+}
+
+// bindViolation records a violation found while binding a request, before
+// any struct field is available.
+func bindViolation(path, pointer string, rule ruleSpec, msg string) Violation {
+	vl := Violation{
+		Field:    path,
+		Pointer:  pointer,
+		Rule:     rule.name,
+		Param:    rule.param,
+		template: msg,
+		label:    path,
+	}
+	vl.Message = vl.render(defaultLocale)
+	return vl
+}
+
+func splitJSONField(field string) []string {
+	if field == "" {
+		return nil
+	}
+	return strings.Split(field, ".")
+}
+
+// resolveJSONPath converts JSON keys and indexes into a Go-style path using
+// the field names of t, and into a JSON pointer.
+func resolveJSONPath(t reflect.Type, keys []string) (string, string) {
+	var path, pointer strings.Builder
+	for _, key := range keys {
+		pointer.WriteString("/" + escapePointer(key))
+
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil {
+			if path.Len() > 0 {
+				path.WriteByte('.')
+			}
+			path.WriteString(key)
+			continue
+		}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			name := key
+			if f, ok := fieldByJSONName(t, key); ok {
+				name, t = f.Name, f.Type
+			} else {
+				t = nil
+			}
+			if path.Len() > 0 {
+				path.WriteByte('.')
+			}
+			path.WriteString(name)
+		case reflect.Slice, reflect.Array, reflect.Map:
+			path.WriteString(fmt.Sprintf("[%s]", key))
+			t = t.Elem()
+		default:
+			path.WriteString("." + key)
+			t = nil
+		}
+	}
+	return path.String(), pointer.String()
+}
+
+// fieldByJSONName finds the field of struct type t decoded from JSON key,
+// matching case-insensitively like encoding/json.
+func fieldByJSONName(t reflect.Type, key string) (reflect.StructField, bool) {
+	var fold reflect.StructField
+	found := false
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Tag.Get("json") == "-" {
+			continue
+		}
+		name := jsonName(f)
+		if name == key {
+			return f, true
+		}
+		if !found && strings.EqualFold(name, key) {
+			fold, found = f, true
+		}
+	}
+	return fold, found
+}
+
+// jsonTypeName describes a Go type with the JSON type it is decoded from.
+func jsonTypeName(t reflect.Type) string {
+	if t == nil {
+		return "value"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	}
+	return "value"
+}
@@ -0,0 +1,241 @@
+package goverify
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+)
+
+// paramSources lists the struct tags read by BindParams, in lookup order.
+var paramSources = []string{"path", "query", "header", "form"}
+
+// defaultMaxMemory is the part of a multipart body kept in memory; the rest
+// of the uploaded files is stored in temporary files.
+const defaultMaxMemory = 32 << 20
+
+type paramLookup struct {
+	values func(source, name string) ([]string, bool)
+	files  func(name string) []*multipart.FileHeader
+}
+
+// BindParams fills the fields of dst tagged with path, query, header or form
+// from r.PathValue, r.URL.Query(), r.Header and r.PostForm, then runs
+// Transform and Validate. Multipart bodies are parsed too: form fields of
+// type *multipart.FileHeader or []*multipart.FileHeader receive the uploaded
+// files, which can be checked with the max_file_size, mime, ext and
+// max_files rules. Form bodies are limited by WithMaxBodySize, and larger
+// ones are rejected with a 413 status.
+//
+// Values are converted to the field type: strings, bools, all numeric kinds,
+// time.Duration, time.Time (RFC 3339, or the layout tag),
+// encoding.TextUnmarshaler implementations, pointers and slices. Slices take
+// every value of a repeated parameter, or a single value split on the sep
+// tag. Parameters that are absent leave the field untouched, and conversion
+// failures are returned as field violations.
+//
+// Example:
+//
+//	type ListOrdersRequest struct {
+//	    TenantID string    `path:"tenant" validator:"required"`
+//	    Page     int       `query:"page" validator:"min_value=1"`
+//	    Status   []string  `query:"status" sep:","`
+//	    Since    time.Time `query:"since" layout:"2006-01-02"`
+//	    TraceID  string    `header:"X-Trace-Id"`
+//	}
+//
+//	req := ListOrdersRequest{Page: 1}
+//	if err := BindParams(r, &req); err != nil {
+//	    WriteError(w, err)
+//	    return
+//	}
+func BindParams(r *http.Request, dst interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	val := reflect.ValueOf(dst)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return NewErr("input must be a pointer to a struct", nil)
+	}
+
+	if err := bindRequestParams(r, val.Elem(), o); err != nil {
+		return err
+	}
+	if err := Transform(dst, opts...); err != nil {
+		return err
+	}
+	if _, err := Validate(dst, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// bindRequestParams fills the tagged fields of the struct val from r without
+// transforming or validating them.
+func bindRequestParams(r *http.Request, val reflect.Value, o *options) error {
+	var formErr error
+	formParsed := false
+	parseForm := func() {
+		if !formParsed {
+			formErr, formParsed = parseRequestForm(r, o.maxBodySize), true
+		}
+	}
+
+	var lookup paramLookup
+	lookup.values = func(source, name string) ([]string, bool) {
+		switch source {
+		case "path":
+			if s := r.PathValue(name); s != "" {
+				return []string{s}, true
+			}
+			return nil, false
+		case "query":
+			values, ok := r.URL.Query()[name]
+			return values, ok
+		case "header":
+			values := r.Header.Values(name)
+			return values, len(values) > 0
+		case "form":
+			parseForm()
+			values, ok := r.PostForm[name]
+			return values, ok
+		}
+		return nil, false
+	}
+	lookup.files = func(name string) []*multipart.FileHeader {
+		parseForm()
+		if r.MultipartForm == nil {
+			return nil
+		}
+		return r.MultipartForm.File[name]
+	}
+
+	violations := bindParams(val, "", "", lookup)
+	var maxErr *http.MaxBytesError
+	if errors.As(formErr, &maxErr) {
+		return newStatusErr(http.StatusRequestEntityTooLarge, "request body too large", nil, o.locale)
+	}
+	if formErr != nil {
+		return newStatusErr(http.StatusBadRequest, "malformed form", nil, o.locale)
+	}
+	if len(violations) > 0 {
+		return newStatusErr(http.StatusBadRequest, "validation failed", violations, o.locale)
+	}
+	return nil
+}
+
+// bindParams sets the tagged fields of val from lookup, descending into
+// nested structs, and returns a violation for every value that failed to
+// convert.
+func bindParams(val reflect.Value, prefix, pointer string, lookup paramLookup) []Violation {
+	var violations []Violation
+	t := val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		field := t.Field(i)
+		fieldVal := val.Field(i)
+		if !fieldVal.CanSet() {
+			continue
+		}
+		path := joinPath(prefix, field.Name)
+		ptr := pointer + "/" + escapePointer(jsonName(field))
+
+		bound := false
+		for _, source := range paramSources {
+			name := field.Tag.Get(source)
+			if name == "" {
+				continue
+			}
+			bound = true
+			if source == "form" && isFileField(fieldVal.Type()) {
+				setFiles(fieldVal, lookup.files(name))
+				break
+			}
+			raw, ok := lookup.values(source, name)
+			if !ok {
+				continue
+			}
+			if err := setFromStrings(fieldVal, field, raw, field.Tag.Get("sep")); err != nil {
+				violations = append(violations, conversionViolation(path, ptr, field, raw))
+			}
+			break
+		}
+
+		if !bound && fieldVal.Kind() == reflect.Struct && fieldVal.Type() != timeType {
+			violations = append(violations, bindParams(fieldVal, path, ptr, lookup)...)
+		}
+	}
+
+	return violations
+}
+
+// clearParamFields zeroes the fields of val that bindParams fills, so that
+// values decoded from a request body cannot stand in for parameters.
+func clearParamFields(val reflect.Value) {
+	t := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		field := t.Field(i)
+		fieldVal := val.Field(i)
+		if !fieldVal.CanSet() {
+			continue
+		}
+
+		if hasParamSource(field) {
+			fieldVal.SetZero()
+		} else if fieldVal.Kind() == reflect.Struct && fieldVal.Type() != timeType {
+			clearParamFields(fieldVal)
+		}
+	}
+}
+
+// hasParamSource reports whether field has one of the paramSources tags.
+func hasParamSource(field reflect.StructField) bool {
+	for _, source := range paramSources {
+		if field.Tag.Get(source) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// conversionViolation reports a raw value that could not be converted to the
+// type of field.
+func conversionViolation(path, pointer string, field reflect.StructField, raw []string) Violation {
+	rule := ruleSpec{name: "type", param: kindName(field.Type)}
+	value := reflect.ValueOf(fmt.Sprint(raw))
+	if len(raw) == 1 {
+		value = reflect.ValueOf(raw[0])
+	}
+	return newViolation(path, pointer, field, rule, "must be a valid {param}", value)
+}
+
+// parseRequestForm parses URL-encoded and multipart bodies of at most
+// maxBodySize bytes into r.PostForm and r.MultipartForm.
+func parseRequestForm(r *http.Request, maxBodySize int64) error {
+	if r.Body != nil && r.Body != http.NoBody {
+		r.Body = http.MaxBytesReader(nil, r.Body, maxBodySize)
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		return r.ParseMultipartForm(defaultMaxMemory)
+	}
+	return r.ParseForm()
+}
+
+func isFileField(t reflect.Type) bool {
+	return t == fileHeaderType || (t.Kind() == reflect.Slice && t.Elem() == fileHeaderType)
+}
+
+// setFiles stores uploaded files into a *multipart.FileHeader (the first
+// file) or []*multipart.FileHeader field.
+func setFiles(v reflect.Value, files []*multipart.FileHeader) {
+	if len(files) == 0 {
+		return
+	}
+	if v.Type() == fileHeaderType {
+		v.Set(reflect.ValueOf(files[0]))
+		return
+	}
+	v.Set(reflect.ValueOf(files))
+}
@@ -0,0 +1,124 @@
+package goverify
+
+// catalogES is the built-in Spanish catalog, keyed by the English templates.
+var catalogES = Catalog{
+	"validation failed":     "la validación falló",
+	"transformation failed": "la transformación falló",
+	"invalid configuration": "configuración no válida",
+
+	"field is required": "el campo es obligatorio",
+
+	"invalid min: {param}":             "min no válido: {param}",
+	"invalid max: {param}":             "max no válido: {param}",
+	"invalid min_value: {param}":       "min_value no válido: {param}",
+	"invalid max_value: {param}":       "max_value no válido: {param}",
+	"length must be at least {param}":  "la longitud debe ser de al menos {param}",
+	"must have at least {param} items": "debe tener al menos {param} elementos",
+	"length must not exceed {param}":   "la longitud no debe superar {param}",
+	"must not exceed {param} items":    "no debe superar {param} elementos",
+	"must be at least {param}":         "debe ser al menos {param}",
+	"must not exceed {param}":          "no debe superar {param}",
+
+	"invalid email format":                           "formato de correo electrónico no válido",
+	"email must not include a display name":          "el correo electrónico no debe incluir un nombre visible",
+	"email domain must be ASCII":                     "el dominio del correo electrónico debe ser ASCII",
+	"email local part must not exceed 64 characters": "la parte local del correo electrónico no debe superar los 64 caracteres",
+	"email must not exceed 254 characters":           "el correo electrónico no debe superar los 254 caracteres",
+	"email must not use plus addressing":             "el correo electrónico no debe usar direcciones con +",
+	"email domain must be one of {param}":            "el dominio del correo electrónico debe ser uno de {param}",
+	"email domain must not be one of {param}":        "el dominio del correo electrónico no debe ser uno de {param}",
+	"invalid email option: {param}":                  "opción de correo electrónico no válida: {param}",
+	"disposable email addresses are not allowed":     "no se permiten direcciones de correo electrónico desechables",
+	"invalid format":                                 "formato no válido",
+
+	"must contain only letters, numbers, and underscores": "solo debe contener letras, números y guiones bajos",
+	"must contain only letters":                           "solo debe contener letras",
+	"must not contain whitespace":                         "no debe contener espacios en blanco",
+	"must contain '{param}'":                              "debe contener '{param}'",
+	"must start with '{param}'":                           "debe comenzar con '{param}'",
+
+	"invalid max_file_size: {param}":        "max_file_size no válido: {param}",
+	"invalid max_files: {param}":            "max_files no válido: {param}",
+	"file must not exceed {param}":          "el archivo no debe superar {param}",
+	"file type must be one of {param}":      "el tipo de archivo debe ser uno de {param}",
+	"file extension must be one of {param}": "la extensión del archivo debe ser una de {param}",
+	"must not have more than {param} files": "no debe tener más de {param} archivos",
+
+	"must be a valid URL":                            "debe ser una URL válida",
+	"URL scheme must be one of {param}":              "el esquema de la URL debe ser uno de {param}",
+	"must be an HTTP or HTTPS URL":                   "debe ser una URL HTTP o HTTPS",
+	"URL must have a host":                           "la URL debe tener un host",
+	"URL must not contain credentials":               "la URL no debe contener credenciales",
+	"URL must not contain a fragment":                "la URL no debe contener un fragmento",
+	"URL must not exceed {param} characters":         "la URL no debe superar los {param} caracteres",
+	"URL host must be in {param}":                    "el host de la URL debe estar en {param}",
+	"invalid max_url_length: {param}":                "max_url_length no válido: {param}",
+	"must be a valid IPv4 address":                   "debe ser una dirección IPv4 válida",
+	"must be a valid IP address":                     "debe ser una dirección IP válida",
+	"must be a valid IPv6 address":                   "debe ser una dirección IPv6 válida",
+	"must be a valid CIDR":                           "debe ser un CIDR válido",
+	"must be a valid IPv4 CIDR":                      "debe ser un CIDR IPv4 válido",
+	"must be a valid IPv6 CIDR":                      "debe ser un CIDR IPv6 válido",
+	"must be a valid MAC address":                    "debe ser una dirección MAC válida",
+	"must be a valid hostname":                       "debe ser un nombre de host válido",
+	"must be a fully qualified domain name":          "debe ser un nombre de dominio completo",
+	"must be a valid port":                           "debe ser un puerto válido",
+	"must be a valid host:port address":              "debe ser una dirección host:puerto válida",
+	"must be a valid TCP address":                    "debe ser una dirección TCP válida",
+	"must be a valid UUID":                           "debe ser un UUID válido",
+	"must be a version {param} UUID":                 "debe ser un UUID de versión {param}",
+	"must be a version 7 UUID":                       "debe ser un UUID de versión 7",
+	"invalid uuid: {param}":                          "uuid no válido: {param}",
+	"must be a valid ULID":                           "debe ser un ULID válido",
+	"must be a valid KSUID":                          "debe ser un KSUID válido",
+	"must be a valid Nano ID":                        "debe ser un Nano ID válido",
+	"invalid nanoid: {param}":                        "nanoid no válido: {param}",
+	"must be a valid snowflake ID":                   "debe ser un ID snowflake válido",
+	"must be a valid card number":                    "debe ser un número de tarjeta válido",
+	"card network must be one of {param}":            "la red de la tarjeta debe ser una de {param}",
+	"invalid credit_card: {param}":                   "credit_card no válido: {param}",
+	"must be a valid IBAN":                           "debe ser un IBAN válido",
+	"must be a valid BIC":                            "debe ser un BIC válido",
+	"must be a valid ISO 4217 currency code":         "debe ser un código de moneda ISO 4217 válido",
+	"must be a valid amount":                         "debe ser un importe válido",
+	"amount has too many decimal places for {param}": "el importe tiene demasiados decimales para {param}",
+	"invalid amount: {param}":                        "amount no válido: {param}",
+	"must be a public IP address":                    "debe ser una dirección IP pública",
+	"must be a private IP address":                   "debe ser una dirección IP privada",
+	"must be a loopback IP address":                  "debe ser una dirección IP de loopback",
+	"IP address must be in {param}":                  "la dirección IP debe estar en {param}",
+	"IP address must not be in {param}":              "la dirección IP no debe estar en {param}",
+	"invalid ip_in: {param}":                         "ip_in no válido: {param}",
+	"invalid ip_not_in: {param}":                     "ip_not_in no válido: {param}",
+
+	"must be a valid ISO 3166-1 alpha-2 country code": "debe ser un código de país ISO 3166-1 alfa-2 válido",
+	"must be a valid ISO 3166-1 alpha-3 country code": "debe ser un código de país ISO 3166-1 alfa-3 válido",
+	"must be a valid ISO 3166-1 numeric country code": "debe ser un código de país ISO 3166-1 numérico válido",
+	"must be a valid ISO 639-1 language code":         "debe ser un código de idioma ISO 639-1 válido",
+	"must be a valid BCP 47 language tag":             "debe ser una etiqueta de idioma BCP 47 válida",
+	"must be a valid ISO 3166-2 subdivision code":     "debe ser un código de subdivisión ISO 3166-2 válido",
+	"must be a valid E.164 phone number":              "debe ser un número de teléfono E.164 válido",
+	"must be a valid {param} phone number":            "debe ser un número de teléfono válido de {param}",
+	"invalid phone: {param}":                          "phone no válido: {param}",
+	"must be a valid {param} postal code":             "debe ser un código postal válido de {param}",
+	"must be a valid postal code":                     "debe ser un código postal válido",
+	"invalid postcode: {param}":                       "postcode no válido: {param}",
+	"invalid postcode_iso3166_field: {param}":         "postcode_iso3166_field no válido: {param}",
+
+	"must be a valid ISO8601 date (YYYY-MM-DD)": "debe ser una fecha ISO8601 válida (AAAA-MM-DD)",
+	"must be a valid time (HH:MM:SS)":           "debe ser una hora válida (HH:MM:SS)",
+
+	"content type must be application/json":            "el tipo de contenido debe ser application/json",
+	"request body is empty":                            "el cuerpo de la solicitud está vacío",
+	"request body too large":                           "el cuerpo de la solicitud es demasiado grande",
+	"request body must contain a single JSON value":    "el cuerpo de la solicitud debe contener un único valor JSON",
+	"malformed JSON":                                   "JSON mal formado",
+	"invalid JSON syntax":                              "sintaxis JSON no válida",
+	"malformed configuration":                          "configuración mal formada",
+	"invalid syntax":                                   "sintaxis no válida",
+	"must be of type {param}":                          "debe ser de tipo {param}",
+	"unknown field":                                    "campo desconocido",
+	"malformed form":                                   "formulario mal formado",
+	"must be a valid {param}":                          "debe ser un valor de tipo {param} válido",
+	"invalid default {value}: must be a valid {param}": "valor por defecto {value} no válido: debe ser un valor de tipo {param} válido",
+}
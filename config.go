@@ -0,0 +1,341 @@
+package goverify
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// LoadConfigFile loads a configuration file into dst (see LoadConfigFS).
+//
+// Example:
+//
+//	var cfg Config
+//	if err := LoadConfigFile("config.toml", &cfg, WithEnvPrefix("APP_")); err != nil {
+//	    log.Fatal(err) // config.toml: validation failed - server.port must be at least 1024
+//	}
+func LoadConfigFile(name string, dst interface{}, opts ...Option) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return loadConfig(name, data, dst, opts)
+}
+
+// LoadConfigFS loads the configuration file name from fsys into dst.
+//
+// Zero-valued fields with a default tag are set first, then the file is
+// decoded on top of them: .json files with encoding/json, and .toml, .ini
+// and .conf files with a simple TOML/INI parser that supports [section] and
+// [[array]] tables, dotted keys, comments, quoted or bare strings, numbers,
+// booleans and single-line arrays. Keys are matched against json tags.
+// Comments start with # or ; at the beginning of a line or after
+// whitespace. Bare strings cannot contain # or ;, so a value such as
+// https://example.com/#top must be quoted; a bare one is a syntax error
+// rather than being cut at the comment character.
+// Environment variables of env tagged fields override the file (see
+// LoadEnv), and finally Transform and Validate run.
+//
+// Errors are returned as one *Err whose message starts with the file name
+// and whose paths are key paths such as servers[3].port. Violations in JSON
+// files carry the line and column of the offending value.
+//
+// Example:
+//
+//	//go:embed config
+//	var configFS embed.FS
+//
+//	var cfg Config
+//	err := LoadConfigFS(configFS, "config/app.json", &cfg)
+func LoadConfigFS(fsys fs.FS, name string, dst interface{}, opts ...Option) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+	return loadConfig(name, data, dst, opts)
+}
+
+func loadConfig(name string, data []byte, dst interface{}, opts []Option) error {
+	o := newOptions(opts)
+
+	val := reflect.ValueOf(dst)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return NewErr("input must be a pointer to a struct", nil)
+	}
+
+	if violations := applyDefaults(val.Elem(), "", ""); len(violations) > 0 {
+		return configErr(name, newViolationErr("invalid configuration", violations, o.locale), nil)
+	}
+
+	isJSON := strings.EqualFold(path.Ext(name), ".json")
+	if isJSON {
+		if err := decodeJSONDocument(data, dst, o); err != nil {
+			return configErr(name, err, nil)
+		}
+	} else {
+		doc, err := parseINI(data)
+		if err != nil {
+			return configErr(name, err, nil)
+		}
+		encoded, _ := json.Marshal(doc)
+		dec := json.NewDecoder(bytes.NewReader(encoded))
+		if o.disallowUnknownFields {
+			dec.DisallowUnknownFields()
+		}
+		if err := dec.Decode(dst); err != nil {
+			return configErr(name, jsonDecodeErr(err, dst, o.locale), nil)
+		}
+	}
+
+	// Environment errors keep the variable names as paths
+	if violations := loadEnv(val.Elem(), "", "", o.envPrefix, o, make(map[string]string)); len(violations) > 0 {
+		e := newViolationErr("validation failed", violations, o.locale)
+		e.Msg = name + ": " + e.Msg
+		return e
+	}
+
+	source := []byte(nil)
+	if isJSON {
+		source = data
+	}
+	if err := Transform(dst, opts...); err != nil {
+		return configErr(name, err, source)
+	}
+	if _, err := Validate(dst, opts...); err != nil {
+		return configErr(name, err, source)
+	}
+	return nil
+}
+
+// configErr prefixes the message of err with the file name and reports its
+// paths as key paths. When source is set, violations get their positions in
+// the JSON document.
+func configErr(name string, err error, source []byte) error {
+	e, ok := err.(*Err)
+	if !ok {
+		return err
+	}
+	if source != nil {
+		attachPositions(e, source)
+	}
+
+	out := e.renamed(func(path string) string {
+		if ptr := e.Pointer(path); ptr != "" {
+			return pointerToKeyPath(ptr)
+		}
+		return path
+	})
+	out.Msg = name + ": " + out.Msg
+	return out
+}
+
+// pointerToKeyPath converts a JSON pointer such as /servers/3/port into the
+// key path servers[3].port.
+func pointerToKeyPath(pointer string) string {
+	var b strings.Builder
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	for _, seg := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		seg = unescape.Replace(seg)
+		if isIndexKey(seg) {
+			b.WriteString("[" + seg + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(seg)
+	}
+	return b.String()
+}
+
+// parseINI parses a TOML/INI-style document into nested maps, with
+// [[name]] tables collected into slices.
+func parseINI(data []byte) (map[string]interface{}, error) {
+	root := make(map[string]interface{})
+	current := root
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		syntaxErr := func() error {
+			vl := bindViolation("", "", ruleSpec{name: "syntax"}, "invalid syntax")
+			vl.Line, vl.Column = lineNo, 1
+			return newStatusErr(http.StatusBadRequest, "malformed configuration", []Violation{vl}, defaultLocale)
+		}
+
+		switch {
+		case strings.HasPrefix(line, "[["):
+			if !strings.HasSuffix(line, "]]") {
+				return nil, syntaxErr()
+			}
+			keys := splitKey(strings.TrimSpace(line[2 : len(line)-2]))
+			parent := tableAt(root, keys[:len(keys)-1])
+			if parent == nil {
+				return nil, syntaxErr()
+			}
+			last := keys[len(keys)-1]
+			list, _ := parent[last].([]interface{})
+			current = make(map[string]interface{})
+			parent[last] = append(list, current)
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return nil, syntaxErr()
+			}
+			if current = tableAt(root, splitKey(strings.TrimSpace(line[1:len(line)-1]))); current == nil {
+				return nil, syntaxErr()
+			}
+		default:
+			key, raw, found := strings.Cut(line, "=")
+			if !found {
+				return nil, syntaxErr()
+			}
+			keys := splitKey(strings.TrimSpace(key))
+			table := tableAt(current, keys[:len(keys)-1])
+			value, ok := parseINIValue(strings.TrimSpace(raw))
+			if table == nil || !ok {
+				return nil, syntaxErr()
+			}
+			table[keys[len(keys)-1]] = value
+		}
+	}
+	return root, scanner.Err()
+}
+
+// tableAt returns the table at keys below m, creating missing tables. The
+// last element of an array of tables is used when a key names one. It
+// returns nil when a key names a value that is not a table, or an empty
+// array.
+func tableAt(m map[string]interface{}, keys []string) map[string]interface{} {
+	for _, key := range keys {
+		switch next := m[key].(type) {
+		case nil:
+			child := make(map[string]interface{})
+			m[key] = child
+			m = child
+		case map[string]interface{}:
+			m = next
+		case []interface{}:
+			if len(next) == 0 {
+				return nil
+			}
+			last, ok := next[len(next)-1].(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			m = last
+		default:
+			return nil
+		}
+	}
+	return m
+}
+
+func splitKey(key string) []string {
+	parts := strings.Split(key, ".")
+	for i, p := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(p), `"'`)
+	}
+	return parts
+}
+
+// parseINIValue parses a quoted or bare string, number, boolean or
+// single-line array. Bare strings holding # or ; are rejected, since they
+// would read differently with a space before the character.
+func parseINIValue(raw string) (interface{}, bool) {
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		s, err := strconv.Unquote(raw)
+		return s, err == nil
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return nil, false
+		}
+		return raw[1 : len(raw)-1], true
+	case strings.HasPrefix(raw, "["):
+		if !strings.HasSuffix(raw, "]") {
+			return nil, false
+		}
+		items := []interface{}{}
+		for _, item := range splitArray(raw[1 : len(raw)-1]) {
+			v, ok := parseINIValue(item)
+			if !ok {
+				return nil, false
+			}
+			items = append(items, v)
+		}
+		return items, true
+	case raw == "true" || raw == "false":
+		return raw == "true", true
+	}
+
+	if n, err := strconv.ParseInt(strings.ReplaceAll(raw, "_", ""), 10, 64); err == nil {
+		return n, true
+	}
+	if f, err := strconv.ParseFloat(strings.ReplaceAll(raw, "_", ""), 64); err == nil {
+		return f, true
+	}
+	return raw, raw != "" && !strings.ContainsAny(raw, "#;")
+}
+
+// splitArray splits the items of an array literal on commas outside quotes.
+func splitArray(s string) []string {
+	var items []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	items = append(items, s[start:])
+
+	out := items[:0]
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// stripComment removes a # or ; comment that is not inside quotes and starts
+// the line or follows whitespace.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case (c == '#' || c == ';') && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,116 @@
+package goverify
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ApplyDefaults sets every zero-valued field of a struct that has a default
+// tag, including fields of nested structs, pointers to structs and slices of
+// structs. The literal is parsed into the field type: strings, bools, all
+// numeric kinds, time.Duration, time.Time (RFC 3339, or the layout tag),
+// encoding.TextUnmarshaler implementations, pointers, and slices split on
+// the sep tag (a comma by default). Defaults that cannot be parsed are
+// configuration errors, returned as an *Err.
+//
+// Call ApplyDefaults before decoding input into the struct: a field that is
+// decoded as an explicit false or 0 afterwards is zero-valued too, and would
+// be overwritten. LoadEnv, LoadConfigFS and BindFlags apply defaults this
+// way.
+//
+// Example:
+//
+//	type ServerConfig struct {
+//	    Region  string        `default:"US"`
+//	    Tags    []string      `default:"general"`
+//	    Timeout time.Duration `default:"30s"`
+//	    Retries *int          `default:"3"`
+//	}
+//
+//	var cfg ServerConfig
+//	if err := ApplyDefaults(&cfg); err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := Bind(r, &cfg); err != nil { // keys in the body override the defaults
+//	    WriteError(w, err)
+//	    return
+//	}
+func ApplyDefaults(dto interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	if dto == nil {
+		return NewErr("invalid payload", nil)
+	}
+
+	val := reflect.ValueOf(dto)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return NewErr("input must be a struct", nil)
+	}
+
+	if violations := applyDefaults(val, "", ""); len(violations) > 0 {
+		return newViolationErr("invalid configuration", violations, o.locale)
+	}
+	return nil
+}
+
+// applyDefaults sets every zero-valued field of val that has a default tag,
+// descending into nested structs (see applyNestedDefaults), and returns a
+// violation for every default that cannot be converted to its field type.
+func applyDefaults(val reflect.Value, prefix, pointer string) []Violation {
+	var violations []Violation
+	t := val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		field := t.Field(i)
+		fieldVal := val.Field(i)
+		if !fieldVal.CanSet() {
+			continue
+		}
+		path := joinPath(prefix, field.Name)
+		ptr := pointer + "/" + escapePointer(jsonName(field))
+
+		def, ok := field.Tag.Lookup("default")
+		if !ok {
+			violations = append(violations, applyNestedDefaults(fieldVal, path, ptr)...)
+			continue
+		}
+		if !fieldVal.IsZero() {
+			continue
+		}
+
+		sep := defaultEnvSeparator
+		if s, hasSep := field.Tag.Lookup("sep"); hasSep {
+			sep = s
+		}
+		if err := setFromStrings(fieldVal, field, []string{def}, sep); err != nil {
+			rule := ruleSpec{name: "default", param: kindName(field.Type)}
+			violations = append(violations, newViolation(path, ptr, field, rule, "invalid default {value}: must be a valid {param}", reflect.ValueOf(def)))
+		}
+	}
+
+	return violations
+}
+
+// applyNestedDefaults applies defaults to a struct, a non-nil pointer to a
+// struct, or the struct elements of a slice.
+func applyNestedDefaults(v reflect.Value, path, pointer string) []Violation {
+	switch {
+	case v.Kind() == reflect.Struct && v.Type() != timeType:
+		return applyDefaults(v, path, pointer)
+	case v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct && v.Elem().Type() != timeType:
+		return applyDefaults(v.Elem(), path, pointer)
+	case v.Kind() == reflect.Slice:
+		var violations []Violation
+		for j := 0; j < v.Len(); j++ {
+			if elem := v.Index(j); elem.Kind() == reflect.Struct || elem.Kind() == reflect.Ptr {
+				violations = append(violations, applyNestedDefaults(elem, fmt.Sprintf("%s[%d]", path, j), fmt.Sprintf("%s/%d", pointer, j))...)
+			}
+		}
+		return violations
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package goverify
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// defaultEnvSeparator splits environment variables into slice elements when
+// a field has no sep tag.
+const defaultEnvSeparator = ","
+
+// LoadEnv fills the fields of dst tagged with env from environment
+// variables, then runs Transform and Validate. Errors of every stage are
+// returned as one *Err whose paths are the environment variable names; a
+// variable that cannot be converted does not keep the other fields from
+// being transformed and validated.
+//
+// Variables are converted like BindParams does; slices are split on the sep
+// tag (a comma by default). Zero-valued fields with a default tag are set
+// first (see ApplyDefaults), so the default applies when a variable is not
+// set or empty. Nested structs are loaded too, with their variables prefixed
+// by the envPrefix tag of the struct field, and WithEnvPrefix prefixes every
+// variable.
+//
+// Example:
+//
+//	type DatabaseConfig struct {
+//	    Host string `env:"HOST" validator:"required"`
+//	    Port int    `env:"PORT" default:"5432" validator:"min_value=1 max_value=65535"`
+//	}
+//
+//	type Config struct {
+//	    Region  string         `env:"REGION" default:"US" transform:"trim uppercase" validator:"iso3166_alpha2"`
+//	    Tags    []string       `env:"TAGS" default:"general"`
+//	    Timeout time.Duration  `env:"TIMEOUT" default:"30s"`
+//	    DB      DatabaseConfig `envPrefix:"DB_"`
+//	}
+//
+//	var cfg Config
+//	if err := LoadEnv(&cfg, WithEnvPrefix("APP_")); err != nil {
+//	    log.Fatal(err) // validation failed - APP_DB_HOST field is required
+//	}
+func LoadEnv(dst interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	val := reflect.ValueOf(dst)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return NewErr("input must be a pointer to a struct", nil)
+	}
+
+	names := make(map[string]string)
+	rename := func(path string) string {
+		if name, ok := names[path]; ok {
+			return name
+		}
+		return path
+	}
+
+	violations := applyDefaults(val.Elem(), "", "")
+	violations = append(violations, loadEnv(val.Elem(), "", "", o.envPrefix, o, names)...)
+	if e := transformAndValidate(dst, violations, opts); e != nil {
+		return e.renamed(rename)
+	}
+	return nil
+}
+
+// transformAndValidate runs Transform and Validate on dst after its fields
+// were loaded, and returns their errors together with the violations found
+// while loading, or nil when there are none. Fields that failed to load are
+// left out of the Transform and Validate errors, so a malformed value is
+// reported once and does not hide the errors of the other fields.
+func transformAndValidate(dst interface{}, violations []Violation, opts []Option) *Err {
+	failed := make(map[string]bool, len(violations))
+	for _, vl := range violations {
+		failed[vl.Field] = true
+	}
+	drop := func(path string) bool {
+		for field := range failed {
+			if path == field || strings.HasPrefix(path, field+".") || strings.HasPrefix(path, field+"[") {
+				return true
+			}
+		}
+		return false
+	}
+
+	all := &Err{}
+	if len(violations) > 0 {
+		all.Merge(newViolationErr("validation failed", violations, newOptions(opts).locale))
+	}
+	var e *Err
+	if err := Transform(dst, opts...); errors.As(err, &e) {
+		all.Merge(e.without(drop))
+	}
+	if _, err := Validate(dst, opts...); errors.As(err, &e) {
+		all.Merge(e.without(drop))
+	}
+	if len(all.Fields) == 0 {
+		return nil
+	}
+	return all
+}
+
+// loadEnv sets the env tagged fields of val from the variables that are set
+// and not empty, recording in names the variable read for each field path,
+// and returns a violation for every variable that failed to convert.
+func loadEnv(val reflect.Value, prefix, pointer, envPrefix string, o *options, names map[string]string) []Violation {
+	var violations []Violation
+	t := val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		field := t.Field(i)
+		fieldVal := val.Field(i)
+		if !fieldVal.CanSet() {
+			continue
+		}
+		path := joinPath(prefix, field.Name)
+		ptr := pointer + "/" + escapePointer(jsonName(field))
+
+		key := field.Tag.Get("env")
+		if key == "" {
+			if fieldVal.Kind() == reflect.Struct && fieldVal.Type() != timeType {
+				violations = append(violations, loadEnv(fieldVal, path, ptr, envPrefix+field.Tag.Get("envPrefix"), o, names)...)
+			}
+			continue
+		}
+
+		name := envPrefix + key
+		names[path] = name
+
+		raw, ok := o.envLookup(name)
+		if !ok || raw == "" {
+			continue
+		}
+
+		sep := defaultEnvSeparator
+		if s, hasSep := field.Tag.Lookup("sep"); hasSep {
+			sep = s
+		}
+		if err := setFromStrings(fieldVal, field, []string{raw}, sep); err != nil {
+			violations = append(violations, conversionViolation(name, ptr, field, []string{raw}))
+		}
+	}
+
+	return violations
+}
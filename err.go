@@ -2,25 +2,333 @@ package goverify
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 )
 
+// ErrValidation is the sentinel matched through errors.Is by every *Err that
+// reports field violations: those returned for failed rules or transformations
+// and those built with NewErr or Add. Errors about malformed input, such as
+// "input must be a struct" or a JSON syntax error, do not match it.
+//
+// Example:
+//
+//	if errors.Is(err, ErrValidation) {
+//	    w.WriteHeader(http.StatusBadRequest)
+//	}
+var ErrValidation = errors.New("validation failed")
+
 // Error implements the error interface for Err.
-// It returns a formatted error message including all field-specific errors.
+// It returns a formatted error message including all field-specific errors,
+// with fields listed in the order returned by Paths.
 func (e *Err) Error() string {
 	if len(e.Fields) == 0 {
 		return e.Msg
 	}
 
 	var fieldErrors []string
-	for field, msgs := range e.Fields {
-		fieldErrors = append(fieldErrors, fmt.Sprintf("%s %s", field, strings.Join(msgs, ", ")))
+	for _, field := range e.Paths() {
+		msgs := strings.Join(e.Fields[field], ", ")
+		if field == "" {
+			fieldErrors = append(fieldErrors, msgs)
+			continue
+		}
+		fieldErrors = append(fieldErrors, fmt.Sprintf("%s %s", field, msgs))
 	}
 
 	return fmt.Sprintf("%s - %s", e.Msg, strings.Join(fieldErrors, "; "))
 }
 
+// Is reports whether target is ErrValidation and the error reports field
+// violations.
+func (e *Err) Is(target error) bool {
+	return target == ErrValidation && e.validation
+}
+
+// Has reports whether path has at least one error message.
+func (e *Err) Has(path string) bool {
+	return len(e.Fields[path]) > 0
+}
+
+// Get returns the error messages recorded for path.
+func (e *Err) Get(path string) []string {
+	return e.Fields[path]
+}
+
+// Len returns the number of paths with error messages.
+func (e *Err) Len() int {
+	return len(e.Fields)
+}
+
+// Paths returns the paths with error messages in a stable order.
+// Numeric indexes are compared by value, so Items[2] sorts before Items[10].
+func (e *Err) Paths() []string {
+	paths := make([]string, 0, len(e.Fields))
+	for path := range e.Fields {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return comparePaths(paths[i], paths[j]) < 0
+	})
+	return paths
+}
+
+// Add records one or more messages for path and returns the error for chaining.
+//
+// Example:
+//
+//	e := &Err{Msg: "validation failed"}
+//	e.Add("Password", "must not match the username")
+func (e *Err) Add(path string, msgs ...string) *Err {
+	if e.Fields == nil {
+		e.Fields = make(map[string][]string)
+	}
+	e.validation = true
+	for _, msg := range msgs {
+		e.Fields[path] = append(e.Fields[path], msg)
+		e.Violations = append(e.Violations, Violation{
+			Field:    path,
+			Pointer:  pathToPointer(path),
+			Message:  msg,
+			template: msg,
+			label:    path,
+			custom:   true,
+		})
+	}
+	return e
+}
+
+// Merge adds the messages of other to the error and returns it for chaining.
+// A nil other is ignored; errors that are not *Err are recorded under the
+// empty path. The receiver's Msg is kept unless it is empty.
+//
+// Example:
+//
+//	all := &Err{Msg: "validation failed"}
+//	_, err := Validate(order)
+//	all.Merge(err)
+//	for i, item := range order.Items {
+//	    if _, err := Validate(item); err != nil {
+//	        all.Merge(err.(*Err).WithPrefix(fmt.Sprintf("Items[%d]", i)))
+//	    }
+//	}
+//	return all.ErrOrNil()
+func (e *Err) Merge(other error) *Err {
+	if other == nil {
+		return e
+	}
+
+	var o *Err
+	if !errors.As(other, &o) {
+		return e.Add("", other.Error())
+	}
+	if o == nil || o == e {
+		return e
+	}
+
+	if e.Msg == "" {
+		e.Msg, e.msgID = o.Msg, o.msgID
+	}
+	if e.status == 0 {
+		e.status = o.status
+	}
+	e.validation = e.validation || o.validation
+	if e.Fields == nil && len(o.Fields) > 0 {
+		e.Fields = make(map[string][]string)
+	}
+
+	covered := make(map[string]bool)
+	for _, vl := range o.Violations {
+		covered[vl.Field] = true
+	}
+	e.Violations = append(e.Violations, o.Violations...)
+	for _, path := range o.Paths() {
+		if !covered[path] {
+			for _, msg := range o.Fields[path] {
+				e.Violations = append(e.Violations, Violation{
+					Field:    path,
+					Pointer:  pathToPointer(path),
+					Message:  msg,
+					template: msg,
+					label:    path,
+					custom:   true,
+				})
+			}
+		}
+		e.Fields[path] = append(e.Fields[path], o.Fields[path]...)
+	}
+	return e
+}
+
+// WithPrefix returns a copy of the error with every path nested under prefix.
+//
+// Example:
+//
+//	e.WithPrefix("items[0]") // "Email" becomes "items[0].Email"
+func (e *Err) WithPrefix(prefix string) *Err {
+	out := &Err{
+		Msg:        e.Msg,
+		msgID:      e.msgID,
+		status:     e.status,
+		validation: e.validation,
+	}
+	if e.Fields != nil {
+		out.Fields = make(map[string][]string, len(e.Fields))
+		for path, msgs := range e.Fields {
+			out.Fields[joinPath(prefix, path)] = append([]string(nil), msgs...)
+		}
+	}
+	if len(e.Violations) > 0 {
+		out.Violations = make([]Violation, len(e.Violations))
+		for i, vl := range e.Violations {
+			vl.Field = joinPath(prefix, vl.Field)
+			vl.Pointer = pathToPointer(prefix) + vl.Pointer
+			out.Violations[i] = vl
+		}
+	}
+	return out
+}
+
+// Pointer returns the JSON pointer of path, taken from its violations when
+// available (so json tag names are used) and derived from the path otherwise.
+func (e *Err) Pointer(path string) string {
+	for _, vl := range e.Violations {
+		if vl.Field == path && vl.Pointer != "" {
+			return vl.Pointer
+		}
+	}
+	return pathToPointer(path)
+}
+
+// MarshalJSON encodes the error message and fields, plus a "pointers" object
+// mapping each field path to its JSON pointer.
+func (e *Err) MarshalJSON() ([]byte, error) {
+	body := struct {
+		Msg      string              `json:"message"`
+		Fields   map[string][]string `json:"fields,omitempty"`
+		Pointers map[string]string   `json:"pointers,omitempty"`
+	}{Msg: e.Msg, Fields: e.Fields}
+	if len(e.Fields) > 0 {
+		body.Pointers = make(map[string]string, len(e.Fields))
+		for path := range e.Fields {
+			body.Pointers[path] = e.Pointer(path)
+		}
+	}
+	return json.Marshal(body)
+}
+
+// renamed returns a copy of the error with each path replaced by rename(path).
+func (e *Err) renamed(rename func(path string) string) *Err {
+	out := &Err{
+		Msg:        e.Msg,
+		msgID:      e.msgID,
+		status:     e.status,
+		validation: e.validation,
+	}
+	if e.Fields != nil {
+		out.Fields = make(map[string][]string, len(e.Fields))
+		for path, msgs := range e.Fields {
+			name := rename(path)
+			out.Fields[name] = append(out.Fields[name], msgs...)
+		}
+	}
+	if len(e.Violations) > 0 {
+		out.Violations = make([]Violation, len(e.Violations))
+		for i, vl := range e.Violations {
+			vl.Field = rename(vl.Field)
+			out.Violations[i] = vl
+		}
+	}
+	return out
+}
+
+// without returns a copy of the error without the messages of the paths for
+// which drop reports true.
+func (e *Err) without(drop func(path string) bool) *Err {
+	out := &Err{
+		Msg:        e.Msg,
+		msgID:      e.msgID,
+		status:     e.status,
+		validation: e.validation,
+	}
+	if e.Fields != nil {
+		out.Fields = make(map[string][]string, len(e.Fields))
+		for path, msgs := range e.Fields {
+			if !drop(path) {
+				out.Fields[path] = append([]string(nil), msgs...)
+			}
+		}
+	}
+	for _, vl := range e.Violations {
+		if !drop(vl.Field) {
+			out.Violations = append(out.Violations, vl)
+		}
+	}
+	return out
+}
+
+// ErrOrNil returns nil when the error has no field messages, and the error
+// itself otherwise. It avoids returning a typed nil from functions that
+// accumulate errors with Add and Merge.
+func (e *Err) ErrOrNil() error {
+	if e == nil || e.Len() == 0 {
+		return nil
+	}
+	return e
+}
+
+// joinPath nests path under prefix using Go-style field and index syntax.
+func joinPath(prefix, path string) string {
+	switch {
+	case prefix == "":
+		return path
+	case path == "":
+		return prefix
+	case strings.HasPrefix(path, "["):
+		return prefix + path
+	default:
+		return prefix + "." + path
+	}
+}
+
+// comparePaths orders paths lexically, comparing runs of digits numerically.
+func comparePaths(a, b string) int {
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			na, ra := splitDigits(a)
+			nb, rb := splitDigits(b)
+			na, nb = strings.TrimLeft(na, "0"), strings.TrimLeft(nb, "0")
+			if len(na) != len(nb) {
+				return len(na) - len(nb)
+			}
+			if c := strings.Compare(na, nb); c != 0 {
+				return c
+			}
+			a, b = ra, rb
+			continue
+		}
+		if a[0] != b[0] {
+			return int(a[0]) - int(b[0])
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) - len(b)
+}
+
+func splitDigits(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
 // NewErr creates a new validation or transformation error.
 // It takes a message and an optional map of field-specific errors.
 //
@@ -32,13 +340,14 @@ func (e *Err) Error() string {
 //	})
 func NewErr(msg string, fields map[string][]string) error {
 	return &Err{
-		Msg:    msg,
-		Fields: fields,
+		Msg:        msg,
+		Fields:     fields,
+		validation: len(fields) > 0,
 	}
 }
 
 // ToJSONErr converts a validation or transformation error to JSON format.
-// Returns an empty byte slice if the error is nil or not of type *Err.
+// Returns an empty byte slice if the error is nil or does not wrap an *Err.
 //
 // Example:
 //
@@ -51,8 +360,8 @@ func ToJSONErr(e error) []byte {
 	if e == nil {
 		return []byte{}
 	}
-	err, ok := e.(*Err)
-	if !ok {
+	var err *Err
+	if !errors.As(e, &err) {
 		return []byte{}
 	}
 	if json, e := json.Marshal(&err); e == nil {
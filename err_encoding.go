@@ -0,0 +1,257 @@
+package goverify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Media types produced by the error encoders.
+const (
+	ContentTypeJSON        = "application/json"
+	ContentTypeProblemJSON = "application/problem+json"
+	ContentTypeJSONAPI     = "application/vnd.api+json"
+)
+
+type (
+	// fieldError is one message for one path, as listed by the encoders.
+	fieldError struct {
+		path    string
+		pointer string
+		rule    string
+		msg     string
+		line    int
+		column  int
+	}
+
+	problemDetails struct {
+		Type   string             `json:"type"`
+		Title  string             `json:"title"`
+		Status int                `json:"status"`
+		Detail string             `json:"detail,omitempty"`
+		Errors []problemViolation `json:"errors,omitempty"`
+	}
+
+	problemViolation struct {
+		Pointer string `json:"pointer,omitempty"`
+		Detail  string `json:"detail"`
+		Code    string `json:"code,omitempty"`
+		Line    int    `json:"line,omitempty"`
+		Column  int    `json:"column,omitempty"`
+	}
+
+	jsonAPIDocument struct {
+		Errors []jsonAPIError `json:"errors"`
+	}
+
+	jsonAPIError struct {
+		Status string         `json:"status"`
+		Code   string         `json:"code,omitempty"`
+		Title  string         `json:"title"`
+		Detail string         `json:"detail,omitempty"`
+		Source *jsonAPISource `json:"source,omitempty"`
+	}
+
+	jsonAPISource struct {
+		Pointer string `json:"pointer"`
+	}
+
+	// pathSegment is a field name or, when index is set, a slice index.
+	pathSegment struct {
+		name  string
+		index bool
+	}
+)
+
+// ToProblemJSON converts an error to an RFC 7807 application/problem+json body.
+// Field violations of an *Err, which may be wrapped, are listed in the
+// "errors" extension member, each addressed by a JSON pointer unless it is
+// not tied to a field. The status member is the one WriteError would use.
+// Other errors are reported as an internal error without exposing their
+// message. Returns an empty byte slice if the error is nil.
+//
+// Example:
+//
+//	_, err := Validate(user)
+//	if err != nil {
+//	    w.Header().Set("Content-Type", ContentTypeProblemJSON)
+//	    w.WriteHeader(http.StatusBadRequest)
+//	    w.Write(ToProblemJSON(err))
+//	}
+func ToProblemJSON(e error) []byte {
+	if e == nil {
+		return []byte{}
+	}
+
+	err, status := errStatus(e)
+	problem := problemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	if err != nil {
+		problem.Title = err.Msg
+		for _, fe := range fieldErrors(err) {
+			problem.Errors = append(problem.Errors, problemViolation{
+				Pointer: fe.pointer,
+				Detail:  fe.msg,
+				Code:    fe.rule,
+				Line:    fe.line,
+				Column:  fe.column,
+			})
+		}
+	}
+
+	if data, err := json.Marshal(problem); err == nil {
+		return data
+	}
+	return []byte{}
+}
+
+// ToJSONAPIErr converts an error to a JSON:API document with an "errors" array.
+// Each field violation of an *Err, which may be wrapped, becomes one error
+// object whose source pointer addresses the attribute under
+// /data/attributes; violations not tied to a field have no source. Error
+// objects carry the status WriteError would use. Returns an empty byte slice
+// if the error is nil.
+//
+// Example:
+//
+//	w.Header().Set("Content-Type", ContentTypeJSONAPI)
+//	w.WriteHeader(http.StatusBadRequest)
+//	w.Write(ToJSONAPIErr(err))
+func ToJSONAPIErr(e error) []byte {
+	if e == nil {
+		return []byte{}
+	}
+
+	doc := jsonAPIDocument{}
+	err, code := errStatus(e)
+	status := strconv.Itoa(code)
+	if err != nil {
+		for _, fe := range fieldErrors(err) {
+			jerr := jsonAPIError{Status: status, Code: fe.rule, Title: err.Msg, Detail: fe.msg}
+			if fe.pointer != "" {
+				jerr.Source = &jsonAPISource{Pointer: "/data/attributes" + fe.pointer}
+			}
+			doc.Errors = append(doc.Errors, jerr)
+		}
+		if len(doc.Errors) == 0 {
+			doc.Errors = append(doc.Errors, jsonAPIError{Status: status, Title: err.Msg})
+		}
+	} else {
+		doc.Errors = append(doc.Errors, jsonAPIError{Status: status, Title: http.StatusText(code)})
+	}
+
+	if data, err := json.Marshal(doc); err == nil {
+		return data
+	}
+	return []byte{}
+}
+
+// ToTextErr renders an error as plain text for CLI tools, with one line
+// per field message below the error message, including the source position
+// of violations found by ValidateJSON. Errors that do not wrap an *Err are
+// rendered with their Error method. Returns an empty string if the error is nil.
+//
+// Example output:
+//
+//	validation failed
+//	  Email: invalid email format
+//	  Servers[3].Port (line 12, column 15): must be at least 1024
+func ToTextErr(e error) string {
+	if e == nil {
+		return ""
+	}
+	var err *Err
+	if !errors.As(e, &err) {
+		return e.Error()
+	}
+
+	var b strings.Builder
+	b.WriteString(err.Msg)
+	for _, fe := range fieldErrors(err) {
+		b.WriteString("\n  ")
+		loc := fe.path
+		if fe.line > 0 {
+			loc = strings.TrimSpace(fmt.Sprintf("%s (line %d, column %d)", fe.path, fe.line, fe.column))
+		}
+		if loc != "" {
+			b.WriteString(loc)
+			b.WriteString(": ")
+		}
+		b.WriteString(fe.msg)
+	}
+	return b.String()
+}
+
+// fieldErrors flattens an Err into one entry per message, ordered by path.
+// Rule names and pointers are taken from the violations when they match the
+// field messages.
+func fieldErrors(e *Err) []fieldError {
+	byPath := make(map[string][]Violation)
+	for _, vl := range e.Violations {
+		byPath[vl.Field] = append(byPath[vl.Field], vl)
+	}
+
+	var out []fieldError
+	for _, path := range e.Paths() {
+		msgs := e.Fields[path]
+		vls := byPath[path]
+		for i, msg := range msgs {
+			fe := fieldError{path: path, pointer: e.Pointer(path), msg: msg}
+			if len(vls) == len(msgs) {
+				fe.rule = vls[i].Rule
+				fe.line, fe.column = vls[i].Line, vls[i].Column
+			}
+			out = append(out, fe)
+		}
+	}
+	return out
+}
+
+// pathToPointer converts a Go-style path such as Items[0].Email into an
+// RFC 6901 JSON pointer such as /Items/0/Email.
+func pathToPointer(path string) string {
+	var b strings.Builder
+	for _, seg := range parsePath(path) {
+		b.WriteByte('/')
+		b.WriteString(escapePointer(seg.name))
+	}
+	return b.String()
+}
+
+// parsePath splits a Go-style path into its field names and indexes.
+func parsePath(path string) []pathSegment {
+	var segs []pathSegment
+	if path == "" {
+		return segs
+	}
+	for _, part := range strings.Split(path, ".") {
+		for part != "" {
+			open := strings.IndexByte(part, '[')
+			if open < 0 {
+				segs = append(segs, pathSegment{name: part})
+				break
+			}
+			if open > 0 {
+				segs = append(segs, pathSegment{name: part[:open]})
+			}
+			end := strings.IndexByte(part[open:], ']')
+			if end < 0 {
+				segs = append(segs, pathSegment{name: part[open:]})
+				break
+			}
+			segs = append(segs, pathSegment{name: part[open+1 : open+end], index: true})
+			part = part[open+end+1:]
+		}
+	}
+	return segs
+}
+
+func escapePointer(seg string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(seg)
+}
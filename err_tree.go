@@ -0,0 +1,193 @@
+package goverify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// TreeErrorsKey is the key holding the messages of a path that also has
+// nested errors, for example a required slice whose items are invalid too.
+const TreeErrorsKey = "_errors"
+
+type treeNode struct {
+	msgs   []string
+	fields map[string]*treeNode
+	items  map[int]*treeNode
+}
+
+// Tree returns the field errors as a nested structure mirroring the input:
+// struct fields become objects, slice elements become arrays (with null for
+// elements without errors) and each leaf holds its list of messages.
+//
+// Example:
+//
+//	// Fields: {"Items[1].Address.City": ["field is required"]}
+//	e.Tree()
+//	// {"Items": [null, {"Address": {"City": ["field is required"]}}]}
+//
+// Messages on a path that also has nested errors are listed under
+// TreeErrorsKey, and a slice carrying such messages becomes an object keyed
+// by index.
+func (e *Err) Tree() map[string]interface{} {
+	root := &treeNode{}
+	for _, path := range e.Paths() {
+		n := root
+		for _, seg := range parsePath(path) {
+			n = n.child(seg)
+		}
+		n.msgs = append(n.msgs, e.Fields[path]...)
+	}
+	return root.object()
+}
+
+// ErrFromTree builds an Err with flat Go-style paths from a tree such as the
+// one returned by Tree, or its JSON decoding.
+//
+// Example:
+//
+//	var body struct {
+//	    Message string                 `json:"message"`
+//	    Fields  map[string]interface{} `json:"fields"`
+//	}
+//	json.Unmarshal(ToJSONTreeErr(err), &body)
+//	flat := ErrFromTree(body.Message, body.Fields)
+//	flat.Has("Items[1].Address.City") // true
+func ErrFromTree(msg string, tree map[string]interface{}) *Err {
+	e := &Err{Msg: msg, Fields: make(map[string][]string)}
+	flattenTree(e, "", tree)
+	return e
+}
+
+// ToJSONTreeErr converts a validation or transformation error to JSON with
+// its fields in tree form (see Err.Tree).
+// Returns an empty byte slice if the error is nil or does not wrap an *Err.
+//
+// Example output:
+//
+//	{"message":"validation failed","fields":{"Items":[{"Email":["invalid email format"]}]}}
+func ToJSONTreeErr(e error) []byte {
+	if e == nil {
+		return []byte{}
+	}
+	var err *Err
+	if !errors.As(e, &err) {
+		return []byte{}
+	}
+
+	body := struct {
+		Msg    string                 `json:"message"`
+		Fields map[string]interface{} `json:"fields,omitempty"`
+	}{Msg: err.Msg}
+	if err.Len() > 0 {
+		body.Fields = err.Tree()
+	}
+
+	if data, e := json.Marshal(body); e == nil {
+		return data
+	}
+	return []byte{}
+}
+
+func (n *treeNode) child(seg pathSegment) *treeNode {
+	if seg.index {
+		if i, err := strconv.Atoi(seg.name); err == nil && i >= 0 {
+			if n.items == nil {
+				n.items = make(map[int]*treeNode)
+			}
+			if n.items[i] == nil {
+				n.items[i] = &treeNode{}
+			}
+			return n.items[i]
+		}
+	}
+	if n.fields == nil {
+		n.fields = make(map[string]*treeNode)
+	}
+	if n.fields[seg.name] == nil {
+		n.fields[seg.name] = &treeNode{}
+	}
+	return n.fields[seg.name]
+}
+
+func (n *treeNode) value() interface{} {
+	switch {
+	case n.fields == nil && n.items == nil:
+		return n.msgs
+	case n.fields == nil && len(n.msgs) == 0:
+		size := 0
+		for i := range n.items {
+			if i+1 > size {
+				size = i + 1
+			}
+		}
+		arr := make([]interface{}, size)
+		for i, item := range n.items {
+			arr[i] = item.value()
+		}
+		return arr
+	default:
+		return n.object()
+	}
+}
+
+func (n *treeNode) object() map[string]interface{} {
+	obj := make(map[string]interface{}, len(n.fields)+len(n.items)+1)
+	for name, child := range n.fields {
+		obj[name] = child.value()
+	}
+	for i, item := range n.items {
+		obj[strconv.Itoa(i)] = item.value()
+	}
+	if len(n.msgs) > 0 {
+		obj[TreeErrorsKey] = n.msgs
+	}
+	return obj
+}
+
+func flattenTree(e *Err, path string, node interface{}) {
+	switch t := node.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return comparePaths(keys[i], keys[j]) < 0 })
+		for _, k := range keys {
+			switch {
+			case k == TreeErrorsKey:
+				flattenTree(e, path, t[k])
+			case isIndexKey(k):
+				flattenTree(e, fmt.Sprintf("%s[%s]", path, k), t[k])
+			default:
+				flattenTree(e, joinPath(path, k), t[k])
+			}
+		}
+	case []string:
+		e.Add(path, t...)
+	case string:
+		e.Add(path, t)
+	case []interface{}:
+		for i, item := range t {
+			if msg, ok := item.(string); ok {
+				e.Add(path, msg)
+			} else if item != nil {
+				flattenTree(e, fmt.Sprintf("%s[%d]", path, i), item)
+			}
+		}
+	}
+}
+
+func isIndexKey(k string) bool {
+	if k == "" {
+		return false
+	}
+	for i := 0; i < len(k); i++ {
+		if !isDigit(k[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,152 @@
+package goverify
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// flagValue adapts a struct field to flag.Value. Conversion failures are
+// recorded as violations instead of aborting flag parsing.
+type flagValue struct {
+	v          reflect.Value
+	field      reflect.StructField
+	name       string
+	pointer    string
+	set        bool
+	violations *[]Violation
+}
+
+// boolFlagValue is a flagValue for bool fields, which can be given as a bare
+// --name on the command line.
+type boolFlagValue struct {
+	*flagValue
+}
+
+// BindFlags applies the default tags of dst (see ApplyDefaults), registers
+// the fields tagged with flag on fs, parses args, then runs Transform and
+// Validate. Errors of every stage are returned as one *Err whose paths are
+// the flag names, so they print as "--port must be at least 1024"; a flag
+// value that cannot be converted does not keep the other fields from being
+// transformed and validated.
+//
+// The usage tag documents the flag and the field value after defaults are
+// applied is shown as the default. Values are converted like
+// BindParams does; slice flags can be repeated or split on the sep tag.
+// Nested structs are registered too, with their flag names prefixed by the
+// flagPrefix tag of the struct field.
+//
+// Example:
+//
+//	type Options struct {
+//	    Port    int      `flag:"port" default:"8080" usage:"port to listen on" validator:"min_value=1024"`
+//	    Host    string   `flag:"host" usage:"interface to bind" transform:"trim lowercase"`
+//	    Verbose bool     `flag:"verbose" usage:"enable debug logging"`
+//	    Tags    []string `flag:"tag" usage:"tag to apply (repeatable)"`
+//	}
+//
+//	var opts Options
+//	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+//	if err := BindFlags(fs, &opts, os.Args[1:]); err != nil {
+//	    fmt.Fprintln(os.Stderr, ToTextErr(err))
+//	    os.Exit(2)
+//	}
+func BindFlags(fs *flag.FlagSet, dst interface{}, args []string, opts ...Option) error {
+	val := reflect.ValueOf(dst)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return NewErr("input must be a pointer to a struct", nil)
+	}
+
+	names := make(map[string]string)
+	rename := func(path string) string {
+		if name, ok := names[path]; ok {
+			return name
+		}
+		return path
+	}
+
+	violations := applyDefaults(val.Elem(), "", "")
+	registerFlags(fs, val.Elem(), "", "", "", names, &violations)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if e := transformAndValidate(dst, violations, opts); e != nil {
+		return e.renamed(rename)
+	}
+	return nil
+}
+
+// registerFlags defines a flag for every flag tagged field of val, recording
+// in names the flag used for each field path.
+func registerFlags(fs *flag.FlagSet, val reflect.Value, prefix, pointer, flagPrefix string, names map[string]string, violations *[]Violation) {
+	t := val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		field := t.Field(i)
+		fieldVal := val.Field(i)
+		if !fieldVal.CanSet() {
+			continue
+		}
+		path := joinPath(prefix, field.Name)
+		ptr := pointer + "/" + escapePointer(jsonName(field))
+
+		key := field.Tag.Get("flag")
+		if key == "" {
+			if fieldVal.Kind() == reflect.Struct && fieldVal.Type() != timeType {
+				registerFlags(fs, fieldVal, path, ptr, flagPrefix+field.Tag.Get("flagPrefix"), names, violations)
+			}
+			continue
+		}
+
+		name := flagPrefix + key
+		names[path] = "--" + name
+
+		fv := &flagValue{v: fieldVal, field: field, name: "--" + name, pointer: ptr, violations: violations}
+
+		if fieldVal.Kind() == reflect.Bool {
+			fs.Var(boolFlagValue{fv}, name, field.Tag.Get("usage"))
+		} else {
+			fs.Var(fv, name, field.Tag.Get("usage"))
+		}
+	}
+}
+
+// String returns the current value of the field.
+func (f *flagValue) String() string {
+	if f == nil || !f.v.IsValid() {
+		return ""
+	}
+	if f.v.Kind() == reflect.Slice {
+		parts := make([]string, f.v.Len())
+		for i := range parts {
+			parts[i] = fmt.Sprint(f.v.Index(i).Interface())
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(f.v.Interface())
+}
+
+// Set converts s into the field. Slices are reset by the first Set from the
+// command line, and later values are appended.
+func (f *flagValue) Set(s string) error {
+	raw := []string{s}
+	if f.v.Kind() == reflect.Slice && f.set {
+		current := reflect.New(f.v.Type()).Elem()
+		if err := setFromStrings(current, f.field, raw, f.field.Tag.Get("sep")); err == nil {
+			f.v.Set(reflect.AppendSlice(f.v, current))
+			return nil
+		}
+	} else if err := setFromStrings(f.v, f.field, raw, f.field.Tag.Get("sep")); err == nil {
+		f.set = true
+		return nil
+	}
+
+	*f.violations = append(*f.violations, conversionViolation(f.name, f.pointer, f.field, raw))
+	return nil
+}
+
+// IsBoolFlag allows bool fields to be set with a bare --name.
+func (b boolFlagValue) IsBoolFlag() bool {
+	return true
+}
@@ -0,0 +1,114 @@
+package goverify
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// StatusCoder can be implemented by response values returned to Handler to
+// choose a status other than 200 OK.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// Handler adapts a typed function to an http.Handler.
+// For every request it allocates a Req, fills it from a JSON body when one is
+// sent (see Bind) and from path, query, header and form tags (see
+// BindParams), then runs Transform and Validate. Fields tagged path, query,
+// header or form are only ever set from their parameter, never from the
+// body. Binding and validation errors are written with WriteErrorFor, in the
+// encoding the client accepts, without calling fn.
+//
+// The value returned by fn is written as JSON with status 200, or with the
+// status of a StatusCoder; a nil value, including a nil pointer such as
+// (*Resp)(nil), writes 204 No Content. Errors returned by fn are written
+// with WriteErrorFor, so an *Err produces a 400 response and any other error
+// a 500.
+//
+// Example:
+//
+//	type CreateOrderRequest struct {
+//	    Tenant   string `path:"tenant" validator:"required"`
+//	    Customer string `json:"customer" validator:"required min=3" transform:"trim"`
+//	}
+//
+//	mux.Handle("POST /tenants/{tenant}/orders", Handler(func(ctx context.Context, req *CreateOrderRequest) (any, error) {
+//	    return orders.Create(ctx, req)
+//	}))
+func Handler[Req any](fn func(ctx context.Context, req *Req) (any, error), opts ...Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := new(Req)
+		if err := bindRequest(r, req, opts); err != nil {
+			WriteErrorFor(w, r, err)
+			return
+		}
+
+		resp, err := fn(r.Context(), req)
+		if err != nil {
+			WriteErrorFor(w, r, err)
+			return
+		}
+		writeJSON(w, resp)
+	})
+}
+
+// bindRequest fills dst from the JSON body and the request parameters, then
+// transforms and validates it. Parameter fields set by the body are cleared
+// before the parameters are bound.
+func bindRequest(r *http.Request, dst interface{}, opts []Option) error {
+	o := newOptions(opts)
+
+	if hasJSONBody(r) {
+		if err := decodeJSONBody(r, dst, o); err != nil {
+			return err
+		}
+	}
+	if val := reflect.ValueOf(dst).Elem(); val.Kind() == reflect.Struct {
+		clearParamFields(val)
+		if err := bindRequestParams(r, val, o); err != nil {
+			return err
+		}
+	}
+
+	if err := Transform(dst, opts...); err != nil {
+		return err
+	}
+	if _, err := Validate(dst, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// hasJSONBody reports whether r carries a body that is not a form.
+func hasJSONBody(r *http.Request) bool {
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType != "application/x-www-form-urlencoded" && !strings.HasPrefix(mediaType, "multipart/")
+}
+
+func writeJSON(w http.ResponseWriter, resp interface{}) {
+	if rv := reflect.ValueOf(resp); resp == nil || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if sc, ok := resp.(StatusCoder); ok {
+		status = sc.StatusCode()
+	}
+	w.Header().Set("Content-Type", ContentTypeJSON)
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -0,0 +1,231 @@
+package goverify
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const defaultLocale = "en"
+
+var catalogs = map[string]Catalog{
+	"en": {},
+	"es": catalogES,
+}
+
+// WithLocale renders error messages using the catalog registered for locale.
+// Locales such as "es-MX" fall back to their base language ("es") and then
+// to the English templates.
+//
+// Example:
+//
+//	valid, err := Validate(user, WithLocale("es"))
+func WithLocale(locale string) Option {
+	return func(o *options) {
+		o.locale = locale
+	}
+}
+
+// RegisterCatalog adds or overrides message templates for a locale.
+// Keys are either the English template returned by a rule or a rule name,
+// in which case the entry replaces every message produced by that rule.
+//
+// Example:
+//
+//	RegisterCatalog("pt", Catalog{
+//	    "field is required":                "o campo é obrigatório",
+//	    "length must be at least {param}":  "o comprimento deve ser de pelo menos {param}",
+//	    "min_value":                        "{field} deve ser pelo menos {param}",
+//	})
+func RegisterCatalog(locale string, catalog Catalog) {
+	c, ok := catalogs[locale]
+	if !ok {
+		c = Catalog{}
+		catalogs[locale] = c
+	}
+	for k, tmpl := range catalog {
+		c[k] = tmpl
+	}
+}
+
+// Translate returns a copy of the error with its messages rendered for locale.
+// Field messages that were not produced by a rule (for example those passed
+// to NewErr) are copied unchanged.
+//
+// Example:
+//
+//	_, err := Validate(user)
+//	if e, ok := err.(*Err); ok {
+//	    fmt.Println(e.Translate("es"))
+//	}
+func (e *Err) Translate(locale string) *Err {
+	out := &Err{
+		Msg:        e.Msg,
+		msgID:      e.msgID,
+		status:     e.status,
+		validation: e.validation,
+	}
+	if e.msgID != "" {
+		out.Msg = localize(locale, e.msgID)
+	}
+
+	if len(e.Violations) > 0 {
+		out.Violations = make([]Violation, len(e.Violations))
+		for i, vl := range e.Violations {
+			vl.Message = vl.render(locale)
+			out.Violations[i] = vl
+		}
+	}
+
+	if e.Fields != nil {
+		out.Fields = make(map[string][]string, len(e.Fields))
+		covered := make(map[string]bool)
+		for _, vl := range out.Violations {
+			covered[vl.Field] = true
+			out.Fields[vl.Field] = append(out.Fields[vl.Field], vl.Message)
+		}
+		for field, msgs := range e.Fields {
+			if !covered[field] {
+				out.Fields[field] = append([]string(nil), msgs...)
+			}
+		}
+	}
+
+	return out
+}
+
+// newViolationErr builds an Err whose Fields are derived from violations.
+// Only validation and transformation failures match ErrValidation; errors
+// such as malformed input or an invalid default tag do not.
+func newViolationErr(msgID string, violations []Violation, locale string) *Err {
+	e := &Err{
+		Msg:        msgID,
+		Fields:     make(map[string][]string),
+		Violations: violations,
+		msgID:      msgID,
+		validation: msgID == "validation failed" || msgID == "transformation failed",
+	}
+	for _, vl := range violations {
+		e.Fields[vl.Field] = append(e.Fields[vl.Field], vl.Message)
+	}
+	if locale != defaultLocale {
+		return e.Translate(locale)
+	}
+	return e
+}
+
+// newViolation records a failed rule for a field, applying any msg and label
+// tag overrides, and renders it in the default locale. The value of a
+// sensitive field is replaced by a placeholder.
+func newViolation(path, pointer string, field reflect.StructField, rule ruleSpec, msg string, value reflect.Value) Violation {
+	vl := Violation{
+		Field:    path,
+		Pointer:  pointer,
+		Rule:     rule.name,
+		Param:    rule.param,
+		template: msg,
+		label:    field.Name,
+	}
+	if label := field.Tag.Get("label"); label != "" {
+		vl.label = label
+	}
+	if override, ok := messageOverride(field, rule.name); ok {
+		vl.template = override
+		vl.custom = true
+	}
+	// Values of sensitive fields are never rendered
+	if _, sensitive := redactMode(field); sensitive {
+		vl.value = redactedValue
+	} else if value.IsValid() && value.CanInterface() {
+		vl.value = value.Interface()
+	}
+	vl.Message = vl.render(defaultLocale)
+	return vl
+}
+
+// render resolves the template for locale and substitutes its placeholders.
+func (vl Violation) render(locale string) string {
+	keys := []string{vl.template}
+	if !vl.custom {
+		keys = []string{vl.Rule, vl.template}
+	}
+	tmpl, ok := lookupMessage(locale, keys...)
+	if !ok {
+		tmpl = vl.template
+	}
+
+	value := ""
+	if vl.value != nil {
+		value = fmt.Sprint(vl.value)
+	}
+
+	return strings.NewReplacer(
+		"{field}", localize(locale, vl.label),
+		"{param}", vl.Param,
+		"{value}", value,
+	).Replace(tmpl)
+}
+
+// messageOverride returns the msg tag template for a rule, if any.
+// The tag is either a single template for every rule of the field, or a
+// semicolon separated list of rule=template pairs.
+//
+// Example:
+//
+//	Username string `validator:"required min=3" msg:"required={field} is mandatory;min=too short"`
+func messageOverride(field reflect.StructField, rule string) (string, bool) {
+	tag := field.Tag.Get("msg")
+	if tag == "" {
+		return "", false
+	}
+
+	rules := make(map[string]bool)
+	for _, r := range parseRules(field.Tag.Get("validator")) {
+		rules[r.name] = true
+	}
+
+	fallback, hasFallback := "", false
+	for _, part := range strings.Split(tag, ";") {
+		part = strings.TrimSpace(part)
+		if name, tmpl, found := strings.Cut(part, "="); found && rules[name] {
+			if name == rule {
+				return tmpl, true
+			}
+			continue
+		}
+		if part != "" {
+			fallback, hasFallback = part, true
+		}
+	}
+	return fallback, hasFallback
+}
+
+// lookupMessage finds the first of keys in the catalog for locale, falling
+// back to its base language and then to English.
+func lookupMessage(locale string, keys ...string) (string, bool) {
+	for _, l := range localeChain(locale) {
+		for _, key := range keys {
+			if tmpl, ok := catalogs[l][key]; ok && key != "" {
+				return tmpl, true
+			}
+		}
+	}
+	return "", false
+}
+
+// localize returns the translation of key for locale, or key itself.
+func localize(locale, key string) string {
+	if tmpl, ok := lookupMessage(locale, key); ok {
+		return tmpl
+	}
+	return key
+}
+
+func localeChain(locale string) []string {
+	locale = strings.ReplaceAll(locale, "_", "-")
+	chain := []string{locale}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		chain = append(chain, base)
+	}
+	return append(chain, defaultLocale)
+}
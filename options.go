@@ -0,0 +1,50 @@
+package goverify
+
+import "os"
+
+// defaultMaxBodySize is the default request body limit: 1 MiB.
+const defaultMaxBodySize = 1 << 20
+
+// WithMaxBodySize limits the number of bytes Bind, BindParams and Handler
+// read from a request body, multipart uploads included. Larger bodies are
+// rejected with a 413 status.
+func WithMaxBodySize(n int64) Option {
+	return func(o *options) {
+		o.maxBodySize = n
+	}
+}
+
+// WithDisallowUnknownFields makes Bind reject JSON objects with keys that do
+// not match any field of the destination struct.
+func WithDisallowUnknownFields() Option {
+	return func(o *options) {
+		o.disallowUnknownFields = true
+	}
+}
+
+// WithEnvPrefix prepends prefix to every environment variable read by LoadEnv.
+func WithEnvPrefix(prefix string) Option {
+	return func(o *options) {
+		o.envPrefix = prefix
+	}
+}
+
+// WithEnvLookup replaces os.LookupEnv as the source of environment variables
+// for LoadEnv, for example to load from a map in tests.
+func WithEnvLookup(lookup func(string) (string, bool)) Option {
+	return func(o *options) {
+		o.envLookup = lookup
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		locale:      defaultLocale,
+		maxBodySize: defaultMaxBodySize,
+		envLookup:   os.LookupEnv,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
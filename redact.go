@@ -0,0 +1,182 @@
+package goverify
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"strings"
+)
+
+// Redaction modes of the redact tag.
+const (
+	RedactFull    = "full"
+	RedactPartial = "partial"
+	RedactHash    = "hash"
+)
+
+// redactedValue replaces the {value} placeholder of sensitive fields.
+const redactedValue = "[REDACTED]"
+
+// redactMode returns the redaction mode of field and whether it is sensitive.
+// A field is sensitive when it has a redact tag, or a sensitive tag other
+// than "false"; the mode defaults to RedactFull.
+func redactMode(field reflect.StructField) (string, bool) {
+	if mode, ok := field.Tag.Lookup("redact"); ok {
+		if mode == "" {
+			mode = RedactFull
+		}
+		return mode, true
+	}
+	if s, ok := field.Tag.Lookup("sensitive"); ok && s != "false" {
+		return RedactFull, true
+	}
+	return "", false
+}
+
+// Redact returns a deep copy of dto with its sensitive fields masked, for
+// safe logging. Fields are sensitive when tagged with redact or sensitive,
+// and their values are never included in validation messages either.
+//
+// The redact tag selects how strings are masked: full (the default) replaces
+// the value with ****, partial keeps the prefix up to the first underscore
+// or dash and the last four characters, and hash replaces the value with a
+// short SHA-256 digest so equal secrets can still be correlated. Slices and
+// maps of strings are masked element by element, and sensitive fields of
+// any other type are set to their zero value. Nested structs, pointers,
+// slices and maps are copied, so dto itself is never modified.
+//
+// Example:
+//
+//	type Credentials struct {
+//	    User     string
+//	    Password string `sensitive:"true" validator:"required min=8"`
+//	    APIKey   string `redact:"partial"`
+//	    Token    string `redact:"hash"`
+//	}
+//
+//	log.Printf("%+v", Redact(creds))
+//	// &{User:john Password:**** APIKey:sk_****1234 Token:sha256:9f86d081884c}
+func Redact(dto interface{}) interface{} {
+	if dto == nil {
+		return nil
+	}
+	return redactValue(reflect.ValueOf(dto)).Interface()
+}
+
+// redactValue returns a deep copy of v with the sensitive fields of every
+// struct it holds masked.
+func redactValue(v reflect.Value) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return out
+		}
+		elem := redactValue(v.Elem())
+		out.Set(reflect.New(elem.Type()))
+		out.Elem().Set(elem)
+	case reflect.Interface:
+		if v.IsNil() {
+			return out
+		}
+		out.Set(redactValue(v.Elem()))
+	case reflect.Struct:
+		out.Set(v)
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field := t.Field(i)
+			fieldVal := out.Field(i)
+			if !fieldVal.CanSet() {
+				continue
+			}
+			if mode, ok := redactMode(field); ok {
+				fieldVal.Set(maskValue(v.Field(i), mode))
+				continue
+			}
+			fieldVal.Set(redactValue(v.Field(i)))
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return out
+		}
+		out.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(redactValue(v.Index(i)))
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(redactValue(v.Index(i)))
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return out
+		}
+		out.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), redactValue(iter.Value()))
+		}
+	default:
+		out.Set(v)
+	}
+
+	return out
+}
+
+// maskValue returns a masked copy of the value of a sensitive field.
+func maskValue(v reflect.Value, mode string) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+
+	switch v.Kind() {
+	case reflect.String:
+		out.SetString(maskString(v.String(), mode))
+	case reflect.Ptr:
+		if !v.IsNil() && v.Elem().Kind() == reflect.String {
+			out.Set(reflect.New(v.Type().Elem()))
+			out.Elem().Set(maskValue(v.Elem(), mode))
+		}
+	case reflect.Slice:
+		if !v.IsNil() && v.Type().Elem().Kind() == reflect.String {
+			out.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				out.Index(i).Set(maskValue(v.Index(i), mode))
+			}
+		}
+	case reflect.Map:
+		if !v.IsNil() && v.Type().Elem().Kind() == reflect.String {
+			out.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+			iter := v.MapRange()
+			for iter.Next() {
+				out.SetMapIndex(iter.Key(), maskValue(iter.Value(), mode))
+			}
+		}
+	}
+
+	return out
+}
+
+// maskString masks s according to mode. Empty strings are kept so logs
+// still show that a value was not set.
+func maskString(s, mode string) string {
+	if s == "" {
+		return s
+	}
+
+	switch mode {
+	case RedactPartial:
+		runes := []rune(s)
+		if len(runes) < 8 {
+			return "****"
+		}
+		prefix := ""
+		if i := strings.IndexAny(s, "_-"); i >= 0 && i < len(runes)/2 {
+			prefix = s[:i+1]
+		}
+		return prefix + "****" + string(runes[len(runes)-4:])
+	case RedactHash:
+		sum := sha256.Sum256([]byte(s))
+		return "sha256:" + hex.EncodeToString(sum[:6])
+	}
+	return "****"
+}
@@ -1,8 +1,6 @@
 package goverify
 
 import (
-	"fmt"
-	"net/url"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -23,7 +21,7 @@ func addSizeRules() {
 				valStr := strings.TrimPrefix(rule, "min=")
 				val, err := strconv.Atoi(valStr)
 				if err != nil {
-					return []string{fmt.Sprintf("invalid min: %s", valStr)}
+					return []string{"invalid min: {param}"}
 				}
 				minLength = val
 				break
@@ -33,11 +31,11 @@ func addSizeRules() {
 		switch v.Kind() {
 		case reflect.String:
 			if len(v.String()) < minLength {
-				errs = append(errs, fmt.Sprintf("length must be at least %d", minLength))
+				errs = append(errs, "length must be at least {param}")
 			}
 		case reflect.Slice, reflect.Array:
 			if v.Len() < minLength {
-				errs = append(errs, fmt.Sprintf("must have at least %d items", minLength))
+				errs = append(errs, "must have at least {param} items")
 			}
 		}
 		return errs
@@ -55,7 +53,7 @@ func addSizeRules() {
 				valStr := strings.TrimPrefix(rule, "max=")
 				val, err := strconv.Atoi(valStr)
 				if err != nil {
-					return []string{fmt.Sprintf("invalid max: %s", valStr)}
+					return []string{"invalid max: {param}"}
 				}
 				maxLength = val
 				break
@@ -65,11 +63,11 @@ func addSizeRules() {
 		switch v.Kind() {
 		case reflect.String:
 			if len(v.String()) > maxLength {
-				errs = append(errs, fmt.Sprintf("length must not exceed %d", maxLength))
+				errs = append(errs, "length must not exceed {param}")
 			}
 		case reflect.Slice, reflect.Array:
 			if v.Len() > maxLength {
-				errs = append(errs, fmt.Sprintf("must not exceed %d items", maxLength))
+				errs = append(errs, "must not exceed {param} items")
 			}
 		}
 		return errs
@@ -89,7 +87,7 @@ func addRangeRules() {
 				valStr := strings.TrimPrefix(rule, "min_value=")
 				val, err := strconv.ParseFloat(valStr, 64)
 				if err != nil {
-					return []string{fmt.Sprintf("invalid min_value: %s", valStr)}
+					return []string{"invalid min_value: {param}"}
 				}
 				minValue = val
 				break
@@ -99,11 +97,11 @@ func addRangeRules() {
 		switch v.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 			if float64(v.Int()) < minValue {
-				errs = append(errs, fmt.Sprintf("must be at least %v", minValue))
+				errs = append(errs, "must be at least {param}")
 			}
 		case reflect.Float32, reflect.Float64:
 			if v.Float() < minValue {
-				errs = append(errs, fmt.Sprintf("must be at least %v", minValue))
+				errs = append(errs, "must be at least {param}")
 			}
 		}
 		return errs
@@ -121,7 +119,7 @@ func addRangeRules() {
 				valStr := strings.TrimPrefix(rule, "max_value=")
 				val, err := strconv.ParseFloat(valStr, 64)
 				if err != nil {
-					return []string{fmt.Sprintf("invalid max_value: %s", valStr)}
+					return []string{"invalid max_value: {param}"}
 				}
 				maxValue = val
 				break
@@ -131,11 +129,11 @@ func addRangeRules() {
 		switch v.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 			if float64(v.Int()) > maxValue {
-				errs = append(errs, fmt.Sprintf("must not exceed %v", maxValue))
+				errs = append(errs, "must not exceed {param}")
 			}
 		case reflect.Float32, reflect.Float64:
 			if v.Float() > maxValue {
-				errs = append(errs, fmt.Sprintf("must not exceed %v", maxValue))
+				errs = append(errs, "must not exceed {param}")
 			}
 		}
 		return errs
@@ -158,31 +156,20 @@ func addRequiredRule() {
 			if v.Float() == 0 {
 				errs = append(errs, "field is required")
 			}
-		case reflect.Slice, reflect.Array:
+		case reflect.Slice, reflect.Array, reflect.Map:
 			if v.Len() == 0 {
 				errs = append(errs, "field is required")
 			}
+		case reflect.Ptr, reflect.Interface:
+			if v.IsNil() {
+				errs = append(errs, "field is required")
+			}
 		}
 		return errs
 	})
 }
 
 func addPatternRules() {
-	// Email format
-	AddRule("email", func(v reflect.Value, field reflect.StructField) []string {
-		var errs []string
-		if v.Kind() != reflect.String {
-			return errs
-		}
-
-		emailPattern := `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
-		re := regexp.MustCompile(emailPattern)
-		if !re.MatchString(v.String()) {
-			errs = append(errs, "invalid email format")
-		}
-		return errs
-	})
-
 	// Regex pattern matching
 	AddRule("pattern", func(v reflect.Value, field reflect.StructField) []string {
 		var errs []string
@@ -258,52 +245,6 @@ func addStringRules() {
 	})
 }
 
-func addNetworkRules() {
-	// URL validation
-	AddRule("url", func(v reflect.Value, field reflect.StructField) []string {
-		var errs []string
-		if v.Kind() != reflect.String {
-			return errs
-		}
-
-		str := v.String()
-		if str == "" {
-			return errs
-		}
-
-		_, err := url.ParseRequestURI(str)
-		if err != nil {
-			errs = append(errs, "must be a valid URL")
-		}
-		return errs
-	})
-
-	// IPv4 validation
-	AddRule("ipv4", func(v reflect.Value, field reflect.StructField) []string {
-		var errs []string
-		if v.Kind() != reflect.String {
-			return errs
-		}
-
-		ipv4Pattern := `^(\d{1,3}\.){3}\d{1,3}$`
-		if matched, _ := regexp.MatchString(ipv4Pattern, v.String()); !matched {
-			errs = append(errs, "must be a valid IPv4 address")
-			return errs
-		}
-
-		// Validate each octet
-		parts := strings.Split(v.String(), ".")
-		for _, part := range parts {
-			num, err := strconv.Atoi(part)
-			if err != nil || num < 0 || num > 255 {
-				errs = append(errs, "must be a valid IPv4 address")
-				break
-			}
-		}
-		return errs
-	})
-}
-
 func addCustomStringRules() {
 	// Contains specific substring
 	AddRule("contains", func(v reflect.Value, field reflect.StructField) []string {
@@ -323,7 +264,7 @@ func addCustomStringRules() {
 		}
 
 		if !strings.Contains(v.String(), substring) {
-			errs = append(errs, fmt.Sprintf("must contain '%s'", substring))
+			errs = append(errs, "must contain '{param}'")
 		}
 		return errs
 	})
@@ -346,7 +287,7 @@ func addCustomStringRules() {
 		}
 
 		if !strings.HasPrefix(v.String(), prefix) {
-			errs = append(errs, fmt.Sprintf("must start with '%s'", prefix))
+			errs = append(errs, "must start with '{param}'")
 		}
 		return errs
 	})
@@ -0,0 +1,122 @@
+package goverify
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+//go:embed data/disposable_domains.txt
+var embeddedDisposableDomains []byte
+
+// disposableDomains is the domain list of the not_disposable_email rule. It
+// is guarded by a lock because it may be reloaded while requests are being
+// validated.
+var disposableDomains = struct {
+	sync.RWMutex
+	set map[string]bool
+}{set: make(map[string]bool)}
+
+func addDisposableRules() {
+	if err := LoadDisposableDomains(bytes.NewReader(embeddedDisposableDomains)); err != nil {
+		panic(err)
+	}
+
+	// Email whose domain, or a parent of it, is not a known disposable domain
+	AddRule("not_disposable_email", func(v reflect.Value, field reflect.StructField) []string {
+		if v.Kind() != reflect.String || v.String() == "" {
+			return nil
+		}
+
+		if domain, ok := emailAddressDomain(v.String()); ok && IsDisposableDomain(domain) {
+			return []string{"disposable email addresses are not allowed"}
+		}
+		return nil
+	})
+}
+
+// LoadDisposableDomains replaces the disposable domain list with the domains
+// read from r, one per line. Blank lines and lines starting with # are
+// ignored. The built-in list is loaded from an embedded file at startup, so
+// this is only needed to use a list of your own, for example one refreshed
+// from an upstream source.
+//
+// Example:
+//
+//	f, err := os.Open("/etc/myapp/disposable_domains.txt")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer f.Close()
+//	if err := LoadDisposableDomains(f); err != nil {
+//	    log.Fatal(err)
+//	}
+func LoadDisposableDomains(r io.Reader) error {
+	set := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if domain, ok := normalizeDomain(line); ok {
+			set[domain] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	disposableDomains.Lock()
+	disposableDomains.set = set
+	disposableDomains.Unlock()
+	return nil
+}
+
+// AddDisposableDomains adds domains to the disposable domain list.
+//
+// Example:
+//
+//	AddDisposableDomains("throwaway.example", "spam.example")
+func AddDisposableDomains(domains ...string) {
+	disposableDomains.Lock()
+	defer disposableDomains.Unlock()
+	for _, d := range domains {
+		if domain, ok := normalizeDomain(d); ok {
+			disposableDomains.set[domain] = true
+		}
+	}
+}
+
+// IsDisposableDomain reports whether domain, or any domain it is a subdomain
+// of, is on the disposable domain list.
+func IsDisposableDomain(domain string) bool {
+	domain, ok := normalizeDomain(domain)
+	if !ok {
+		return false
+	}
+
+	disposableDomains.RLock()
+	defer disposableDomains.RUnlock()
+	for {
+		if disposableDomains.set[domain] {
+			return true
+		}
+		i := strings.IndexByte(domain, '.')
+		if i < 0 {
+			return false
+		}
+		domain = domain[i+1:]
+	}
+}
+
+// normalizeDomain lowercases a domain, converts it to ASCII and removes a
+// trailing dot.
+func normalizeDomain(domain string) (string, bool) {
+	ascii, err := domainToASCII(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	return ascii, err == nil && ascii != ""
+}
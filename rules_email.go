@@ -0,0 +1,133 @@
+package goverify
+
+import (
+	"net/mail"
+	"net/netip"
+	"reflect"
+	"strings"
+)
+
+// RFC 5321 limits on the length of an address and its local part.
+const (
+	maxEmailLength      = 254
+	maxEmailLocalLength = 64
+)
+
+// emailOptions are the policy options of the email rule.
+var emailOptions = map[string]bool{
+	"allow_name": true, // accept a display name, as in John <john@example.com>
+	"allow_ip":   true, // accept domain literals, as in john@[192.0.2.1]
+	"no_plus":    true, // reject plus-addressing, as in john+news@example.com
+	"no_idn":     true, // reject internationalized domain names
+}
+
+func addEmailRules() {
+	// RFC 5322 address, with policy options such as email=allow_name no_plus
+	addListRule("email", func(v reflect.Value, field reflect.StructField) []string {
+		if v.Kind() != reflect.String {
+			return nil
+		}
+
+		param, _ := ruleParam(field, "email")
+		policy := make(map[string]bool)
+		for _, opt := range strings.Fields(param) {
+			if !emailOptions[opt] {
+				return []string{"invalid email option: {param}"}
+			}
+			policy[opt] = true
+		}
+
+		addr, err := mail.ParseAddress(v.String())
+		if err != nil {
+			return []string{"invalid email format"}
+		}
+		if !policy["allow_name"] && (addr.Name != "" || strings.ContainsAny(v.String(), "<>") || v.String() != strings.TrimSpace(v.String())) {
+			return []string{"email must not include a display name"}
+		}
+
+		at := strings.LastIndexByte(addr.Address, '@')
+		local, domain := addr.Address[:at], addr.Address[at+1:]
+		if !isASCII(domain) && policy["no_idn"] {
+			return []string{"email domain must be ASCII"}
+		}
+		ascii, ok := emailDomain(domain, policy["allow_ip"])
+		if !ok {
+			return []string{"invalid email format"}
+		}
+		if len(local) > maxEmailLocalLength {
+			return []string{"email local part must not exceed 64 characters"}
+		}
+		if len(local)+1+len(ascii) > maxEmailLength {
+			return []string{"email must not exceed 254 characters"}
+		}
+		if policy["no_plus"] && strings.Contains(local, "+") {
+			return []string{"email must not use plus addressing"}
+		}
+		return nil
+	})
+
+	// Email domain equal to or a subdomain of one of the listed domains
+	addListRule("email_domain_in", func(v reflect.Value, field reflect.StructField) []string {
+		if v.Kind() != reflect.String || v.String() == "" {
+			return nil
+		}
+
+		param, _ := ruleParam(field, "email_domain_in")
+		domain, ok := emailAddressDomain(v.String())
+		if !ok || !inDomains(domain, asciiDomains(param)) {
+			return []string{"email domain must be one of {param}"}
+		}
+		return nil
+	})
+
+	// Email domain outside all of the listed domains and their subdomains
+	addListRule("email_domain_not_in", func(v reflect.Value, field reflect.StructField) []string {
+		if v.Kind() != reflect.String || v.String() == "" {
+			return nil
+		}
+
+		param, _ := ruleParam(field, "email_domain_not_in")
+		if domain, ok := emailAddressDomain(v.String()); ok && inDomains(domain, asciiDomains(param)) {
+			return []string{"email domain must not be one of {param}"}
+		}
+		return nil
+	})
+}
+
+// emailDomain returns the ASCII form of the domain of an address, which
+// must be a fully qualified domain name or, when allowIP is set, an address
+// literal such as [192.0.2.1] or [IPv6:2001:db8::1].
+func emailDomain(domain string, allowIP bool) (string, bool) {
+	if strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]") {
+		literal := strings.TrimPrefix(domain[1:len(domain)-1], "IPv6:")
+		_, err := netip.ParseAddr(literal)
+		return domain, allowIP && err == nil
+	}
+
+	ascii, err := domainToASCII(domain)
+	if err != nil || !isFQDN(ascii) || strings.HasSuffix(ascii, ".") {
+		return "", false
+	}
+	return ascii, true
+}
+
+// emailAddressDomain returns the lowercased ASCII domain of an address.
+func emailAddressDomain(s string) (string, bool) {
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return "", false
+	}
+	domain := addr.Address[strings.LastIndexByte(addr.Address, '@')+1:]
+	return emailDomain(domain, true)
+}
+
+// asciiDomains converts a space separated list of domains to ASCII.
+func asciiDomains(param string) []string {
+	domains := strings.Fields(param)
+	for i, d := range domains {
+		if ascii, err := domainToASCII(d); err == nil {
+			domains[i] = ascii
+		}
+	}
+	return domains
+}
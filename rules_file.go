@@ -0,0 +1,158 @@
+package goverify
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var fileHeaderType = reflect.TypeOf((*multipart.FileHeader)(nil))
+
+var sizeUnits = map[string]int64{
+	"":   1,
+	"B":  1,
+	"KB": 1 << 10,
+	"MB": 1 << 20,
+	"GB": 1 << 30,
+}
+
+func addFileRules() {
+	// Maximum size of each uploaded file
+	AddRule("max_file_size", func(v reflect.Value, field reflect.StructField) []string {
+		var errs []string
+		param, _ := ruleParam(field, "max_file_size")
+		limit, err := parseSize(param)
+		if err != nil {
+			return []string{"invalid max_file_size: {param}"}
+		}
+
+		for _, fh := range fileHeaders(v) {
+			if fh.Size > limit {
+				errs = append(errs, "file must not exceed {param}")
+				break
+			}
+		}
+		return errs
+	})
+
+	// Allowed content types, detected from the file contents
+	addListRule("mime", func(v reflect.Value, field reflect.StructField) []string {
+		var errs []string
+		param, _ := ruleParam(field, "mime")
+		allowed := strings.Fields(param)
+
+		for _, fh := range fileHeaders(v) {
+			mediaType, err := sniffContentType(fh)
+			if err != nil || !matchMediaType(mediaType, allowed) {
+				errs = append(errs, "file type must be one of {param}")
+				break
+			}
+		}
+		return errs
+	})
+
+	// Allowed file name extensions
+	addListRule("ext", func(v reflect.Value, field reflect.StructField) []string {
+		var errs []string
+		param, _ := ruleParam(field, "ext")
+		allowed := strings.Fields(strings.ToLower(param))
+
+		for _, fh := range fileHeaders(v) {
+			ext := strings.ToLower(filepath.Ext(fh.Filename))
+			if !slices.Contains(allowed, ext) {
+				errs = append(errs, "file extension must be one of {param}")
+				break
+			}
+		}
+		return errs
+	})
+
+	// Maximum number of uploaded files
+	AddRule("max_files", func(v reflect.Value, field reflect.StructField) []string {
+		var errs []string
+		param, _ := ruleParam(field, "max_files")
+		limit, err := strconv.Atoi(param)
+		if err != nil {
+			return []string{"invalid max_files: {param}"}
+		}
+
+		if len(fileHeaders(v)) > limit {
+			errs = append(errs, "must not have more than {param} files")
+		}
+		return errs
+	})
+}
+
+// fileHeaders returns the uploaded files held by a *multipart.FileHeader or
+// []*multipart.FileHeader value.
+func fileHeaders(v reflect.Value) []*multipart.FileHeader {
+	switch {
+	case v.Type() == fileHeaderType:
+		if fh, _ := v.Interface().(*multipart.FileHeader); fh != nil {
+			return []*multipart.FileHeader{fh}
+		}
+	case v.Kind() == reflect.Slice && v.Type().Elem() == fileHeaderType:
+		var files []*multipart.FileHeader
+		for i := 0; i < v.Len(); i++ {
+			if fh, _ := v.Index(i).Interface().(*multipart.FileHeader); fh != nil {
+				files = append(files, fh)
+			}
+		}
+		return files
+	}
+	return nil
+}
+
+// parseSize parses sizes such as 512, 100KB or 5MB (binary multiples).
+func parseSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	i := len(s)
+	for i > 0 && (s[i-1] < '0' || s[i-1] > '9') {
+		i--
+	}
+	unit, ok := sizeUnits[strings.TrimSpace(s[i:])]
+	if !ok {
+		return 0, strconv.ErrSyntax
+	}
+	n, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return int64(n * float64(unit)), nil
+}
+
+// sniffContentType detects the media type of an upload from its first
+// 512 bytes, ignoring the Content-Type sent by the client.
+func sniffContentType(fh *multipart.FileHeader) (string, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && n == 0 {
+		return "", err
+	}
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(buf[:n]))
+	return mediaType, err
+}
+
+// matchMediaType reports whether mediaType is listed in allowed, which may
+// contain wildcards such as image/*.
+func matchMediaType(mediaType string, allowed []string) bool {
+	for _, a := range allowed {
+		a = strings.ToLower(a)
+		if a == mediaType || (strings.HasSuffix(a, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(a, "*"))) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,258 @@
+package goverify
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	//go:embed data/iso4217.txt
+	iso4217Table []byte
+	//go:embed data/iban_lengths.txt
+	ibanTable []byte
+
+	// currencyMinorUnits maps ISO 4217 codes to their number of decimal
+	// places, or -1 for codes without minor units.
+	currencyMinorUnits = make(map[string]int)
+	// ibanLengths maps country codes to the length of their IBANs.
+	ibanLengths = make(map[string]int)
+)
+
+// cardNetwork describes the number ranges of a card network. Each range is
+// a pair of prefixes of equal length, compared with the same number of
+// leading digits of the card number.
+type cardNetwork struct {
+	name    string
+	ranges  [][2]string
+	lengths []int
+}
+
+// cardNetworks is ordered so that CardNetwork reports the most specific
+// network first; maestro overlaps several others and comes last.
+var cardNetworks = []cardNetwork{
+	{"visa", [][2]string{{"4", "4"}}, []int{13, 16, 19}},
+	{"mastercard", [][2]string{{"51", "55"}, {"2221", "2720"}}, []int{16}},
+	{"amex", [][2]string{{"34", "34"}, {"37", "37"}}, []int{15}},
+	{"discover", [][2]string{{"6011", "6011"}, {"644", "649"}, {"65", "65"}, {"622126", "622925"}}, []int{16, 17, 18, 19}},
+	{"diners", [][2]string{{"300", "305"}, {"36", "36"}, {"38", "39"}}, []int{14, 15, 16, 17, 18, 19}},
+	{"jcb", [][2]string{{"3528", "3589"}}, []int{16, 17, 18, 19}},
+	{"unionpay", [][2]string{{"62", "62"}}, []int{16, 17, 18, 19}},
+	{"maestro", [][2]string{{"50", "50"}, {"56", "69"}}, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+}
+
+func addFinanceRules() {
+	for _, row := range loadTable(iso4217Table) {
+		units, err := strconv.Atoi(row[1])
+		if err != nil {
+			units = -1
+		}
+		currencyMinorUnits[row[0]] = units
+	}
+	for _, row := range loadTable(ibanTable) {
+		n, _ := strconv.Atoi(row[1])
+		ibanLengths[row[0]] = n
+	}
+
+	// Card number passing the Luhn check, optionally of the listed networks
+	// (credit_card=visa mastercard). Spaces and hyphens are ignored.
+	addListRule("credit_card", func(v reflect.Value, field reflect.StructField) []string {
+		param, _ := ruleParam(field, "credit_card")
+		networks := strings.Fields(strings.ToLower(param))
+		for _, name := range networks {
+			if !slices.ContainsFunc(cardNetworks, func(n cardNetwork) bool { return n.name == name }) {
+				return []string{"invalid credit_card: {param}"}
+			}
+		}
+		if v.Kind() != reflect.String || v.String() == "" {
+			return nil
+		}
+
+		number := strings.NewReplacer(" ", "", "-", "").Replace(v.String())
+		if len(number) < 12 || len(number) > 19 || !luhnValid(number) {
+			return []string{"must be a valid card number"}
+		}
+		if len(networks) == 0 {
+			return nil
+		}
+		for _, n := range cardNetworks {
+			if slices.Contains(networks, n.name) && n.matches(number) {
+				return nil
+			}
+		}
+		return []string{"card network must be one of {param}"}
+	})
+
+	// IBAN with the length of its country and valid mod-97 check digits, in
+	// electronic or space separated print format
+	addStringRule("iban", "must be a valid IBAN", func(s string) bool {
+		iban := strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+		if len(iban) < 5 || ibanLengths[iban[:2]] != len(iban) || !isDigit(iban[2]) || !isDigit(iban[3]) {
+			return false
+		}
+		return ibanMod97(iban[4:]+iban[:4]) == 1
+	})
+
+	// 8 or 11 character ISO 9362 business identifier code of a known country
+	addStringRule("bic", "must be a valid BIC", func(s string) bool {
+		if len(s) != 8 && len(s) != 11 {
+			return false
+		}
+		if c, ok := countries[s[4:6]]; (!ok || c.Alpha2 != s[4:6]) && s[4:6] != "XK" {
+			return false
+		}
+		for i := 0; i < len(s); i++ {
+			c := s[i]
+			letter := c >= 'A' && c <= 'Z'
+			if (i < 6 && !letter) || (i >= 6 && !letter && !isDigit(c)) {
+				return false
+			}
+		}
+		return true
+	})
+
+	// Active ISO 4217 currency code
+	addStringRule("iso4217", "must be a valid ISO 4217 currency code", func(s string) bool {
+		_, ok := currencyMinorUnits[s]
+		return ok
+	})
+
+	// Amount with no more decimal places than the minor units of the given
+	// currency (amount=JPY), as a decimal string or a number
+	AddRule("amount", func(v reflect.Value, field reflect.StructField) []string {
+		param, _ := ruleParam(field, "amount")
+		units, ok := currencyMinorUnits[strings.ToUpper(param)]
+		if !ok {
+			return []string{"invalid amount: {param}"}
+		}
+
+		var s string
+		switch v.Kind() {
+		case reflect.String:
+			if s = v.String(); s == "" {
+				return nil
+			}
+		case reflect.Float32, reflect.Float64:
+			s = strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+		default:
+			return nil
+		}
+
+		decimals, ok := amountDecimals(s)
+		if !ok {
+			return []string{"must be a valid amount"}
+		}
+		if units >= 0 && decimals > units {
+			return []string{"amount has too many decimal places for {param}"}
+		}
+		return nil
+	})
+}
+
+// CurrencyMinorUnits returns the number of decimal places of an ISO 4217
+// currency, such as 2 for USD and 0 for JPY, or -1 for codes without minor
+// units. It reports false for unknown codes.
+func CurrencyMinorUnits(code string) (int, bool) {
+	units, ok := currencyMinorUnits[strings.ToUpper(code)]
+	return units, ok
+}
+
+// CardNetwork returns the network of a card number, such as visa or amex,
+// or an empty string when no network matches. It does not run the Luhn
+// check; use the credit_card rule for that.
+func CardNetwork(number string) string {
+	number = strings.NewReplacer(" ", "", "-", "").Replace(number)
+	for _, n := range cardNetworks {
+		if n.matches(number) {
+			return n.name
+		}
+	}
+	return ""
+}
+
+// matches reports whether number has one of the lengths and prefixes of n.
+func (n cardNetwork) matches(number string) bool {
+	if !slices.Contains(n.lengths, len(number)) {
+		return false
+	}
+	for _, r := range n.ranges {
+		if prefix := number[:len(r[0])]; prefix >= r[0] && prefix <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// luhnValid reports whether a string of digits passes the Luhn checksum.
+func luhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		if !isDigit(number[i]) {
+			return false
+		}
+		d := int(number[i] - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// ibanMod97 computes the ISO 7064 mod 97-10 remainder of an IBAN whose
+// country code and check digits were moved to the end, with letters
+// counting as 10 to 35. It returns -1 for other characters.
+func ibanMod97(s string) int {
+	rem := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case isDigit(c):
+			rem = (rem*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			rem = (rem*100 + int(c-'A') + 10) % 97
+		default:
+			return -1
+		}
+	}
+	return rem
+}
+
+// amountDecimals returns the number of decimal places of a plain decimal
+// number such as -12.50.
+func amountDecimals(s string) (int, bool) {
+	whole, frac, hasFrac := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	if whole == "" || (hasFrac && frac == "") {
+		return 0, false
+	}
+	for _, part := range []string{whole, frac} {
+		for i := 0; i < len(part); i++ {
+			if !isDigit(part[i]) {
+				return 0, false
+			}
+		}
+	}
+	return len(frac), true
+}
+
+// loadTable splits an embedded reference table into the tab separated
+// fields of its lines, skipping blank lines and # comments.
+func loadTable(data []byte) [][]string {
+	var rows [][]string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rows = append(rows, strings.Split(line, "\t"))
+	}
+	return rows
+}
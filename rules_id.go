@@ -0,0 +1,178 @@
+package goverify
+
+import (
+	"encoding/hex"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// crockfordAlphabet is the Base32 alphabet of ULIDs.
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// nanoIDAlphabet is the default URL-safe alphabet of Nano IDs.
+	nanoIDAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_-"
+	// maxKSUID is the largest KSUID, the Base62 form of 20 0xff bytes.
+	maxKSUID = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+)
+
+func addIDRules() {
+	// Canonical 8-4-4-4-12 UUID, optionally of the listed versions (uuid=4 7)
+	addListRule("uuid", func(v reflect.Value, field reflect.StructField) []string {
+		if v.Kind() != reflect.String || v.String() == "" {
+			return nil
+		}
+
+		param, _ := ruleParam(field, "uuid")
+		var versions []int
+		for _, p := range strings.Fields(param) {
+			n, err := strconv.Atoi(p)
+			if err != nil || n < 1 || n > 8 {
+				return []string{"invalid uuid: {param}"}
+			}
+			versions = append(versions, n)
+		}
+
+		version, ok := uuidVersion(v.String())
+		if !ok {
+			return []string{"must be a valid UUID"}
+		}
+		if len(versions) > 0 && !slices.Contains(versions, version) {
+			return []string{"must be a version {param} UUID"}
+		}
+		return nil
+	})
+
+	// Time-ordered version 7 UUID
+	addStringRule("uuid7", "must be a version 7 UUID", func(s string) bool {
+		version, ok := uuidVersion(s)
+		return ok && version == 7
+	})
+
+	// 26 character Crockford Base32 ULID whose timestamp fits 48 bits
+	addStringRule("ulid", "must be a valid ULID", func(s string) bool {
+		return len(s) == 26 && s[0] <= '7' && strings.Trim(strings.ToUpper(s), crockfordAlphabet) == ""
+	})
+
+	// 27 character Base62 KSUID no greater than the largest 20 byte value
+	addStringRule("ksuid", "must be a valid KSUID", func(s string) bool {
+		if len(s) != 27 || s > maxKSUID {
+			return false
+		}
+		for i := 0; i < len(s); i++ {
+			if c := s[i]; !isDigit(c) && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') {
+				return false
+			}
+		}
+		return true
+	})
+
+	// Nano ID of the given length (21 by default) in the URL-safe alphabet
+	AddRule("nanoid", func(v reflect.Value, field reflect.StructField) []string {
+		param, ok := ruleParam(field, "nanoid")
+		size := 21
+		if ok && param != "" {
+			n, err := strconv.Atoi(param)
+			if err != nil || n < 1 {
+				return []string{"invalid nanoid: {param}"}
+			}
+			size = n
+		}
+		if v.Kind() != reflect.String || v.String() == "" {
+			return nil
+		}
+
+		s := v.String()
+		if len(s) != size || strings.Trim(s, nanoIDAlphabet) != "" {
+			return []string{"must be a valid Nano ID"}
+		}
+		return nil
+	})
+
+	// Positive 63 bit snowflake ID, as a decimal string or an integer
+	AddRule("snowflake", func(v reflect.Value, field reflect.StructField) []string {
+		var errs []string
+		switch v.Kind() {
+		case reflect.String:
+			if s := v.String(); s != "" {
+				if n, err := strconv.ParseInt(s, 10, 64); err != nil || n <= 0 || s[0] == '0' || s[0] == '+' {
+					errs = append(errs, "must be a valid snowflake ID")
+				}
+			}
+		case reflect.Int, reflect.Int64:
+			if v.Int() <= 0 {
+				errs = append(errs, "must be a valid snowflake ID")
+			}
+		case reflect.Uint, reflect.Uint64:
+			if n := v.Uint(); n == 0 || n > 1<<63-1 {
+				errs = append(errs, "must be a valid snowflake ID")
+			}
+		}
+		return errs
+	})
+}
+
+func addIDTransformers() {
+	// Canonical lowercase UUID from braced, URN, uppercase or unhyphenated forms
+	AddTransformer("uuid", func(v reflect.Value) error {
+		if v.Kind() != reflect.String {
+			return nil
+		}
+		if canonical, ok := canonicalUUID(v.String()); ok {
+			v.SetString(canonical)
+		}
+		return nil
+	})
+
+	// Canonical uppercase ULID, mapping the Crockford aliases I and L to 1
+	// and O to 0
+	AddTransformer("ulid", func(v reflect.Value) error {
+		if v.Kind() != reflect.String {
+			return nil
+		}
+		s := strings.ToUpper(strings.TrimSpace(v.String()))
+		if len(s) == 26 {
+			s = strings.NewReplacer("I", "1", "L", "1", "O", "0").Replace(s)
+		}
+		v.SetString(s)
+		return nil
+	})
+}
+
+// uuidVersion returns the version of a UUID in canonical 8-4-4-4-12 form.
+// Apart from the nil and max UUIDs, the variant must be the RFC 9562 one.
+func uuidVersion(s string) (int, bool) {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return 0, false
+	}
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil {
+		return 0, false
+	}
+
+	lower := strings.ToLower(s)
+	if lower == "00000000-0000-0000-0000-000000000000" || lower == "ffffffff-ffff-ffff-ffff-ffffffffffff" {
+		return 0, true
+	}
+	version := int(b[6] >> 4)
+	return version, version >= 1 && version <= 8 && b[8]&0xc0 == 0x80
+}
+
+// canonicalUUID converts the common UUID spellings to the lowercase
+// hyphenated form.
+func canonicalUUID(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if len(s) > 9 && strings.EqualFold(s[:9], "urn:uuid:") {
+		s = s[9:]
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+	s = strings.ToLower(strings.ReplaceAll(s, "-", ""))
+	if len(s) != 32 {
+		return "", false
+	}
+	if _, err := hex.DecodeString(s); err != nil {
+		return "", false
+	}
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:], true
+}
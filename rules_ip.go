@@ -0,0 +1,325 @@
+package goverify
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ipRanges holds the named ranges usable in ip_in and ip_not_in.
+var ipRanges = map[string][]netip.Prefix{
+	"private":     mustPrefixes("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"),
+	"loopback":    mustPrefixes("127.0.0.0/8", "::1/128"),
+	"link_local":  mustPrefixes("169.254.0.0/16", "fe80::/10"),
+	"multicast":   mustPrefixes("224.0.0.0/4", "ff00::/8"),
+	"unspecified": mustPrefixes("0.0.0.0/32", "::/128"),
+	"cloud_metadata": mustPrefixes(
+		"169.254.169.254/32", // AWS, GCP, Azure, OpenStack
+		"169.254.170.2/32",   // AWS ECS task metadata
+		"100.100.100.200/32", // Alibaba Cloud
+		"fd00:ec2::254/128",  // AWS over IPv6
+	),
+	"reserved": mustPrefixes(
+		"0.0.0.0/8",       // this network
+		"100.64.0.0/10",   // carrier-grade NAT
+		"192.0.0.0/24",    // IETF protocol assignments
+		"192.0.2.0/24",    // TEST-NET-1
+		"198.18.0.0/15",   // benchmarking
+		"198.51.100.0/24", // TEST-NET-2
+		"203.0.113.0/24",  // TEST-NET-3
+		"240.0.0.0/4",     // future use and broadcast
+		"64:ff9b:1::/48",  // local-use IPv4/IPv6 translation
+		"100::/64",        // discard-only
+		"2001::/23",       // IETF protocol assignments
+		"2001:db8::/32",   // documentation
+		"3fff::/20",       // documentation
+	),
+}
+
+// nonPublicRanges are the named ranges rejected by ip_public.
+var nonPublicRanges = []string{"private", "loopback", "link_local", "multicast", "unspecified", "cloud_metadata", "reserved"}
+
+// RegisterIPRange adds or replaces a named range for the ip_in and
+// ip_not_in rules. Each entry is a CIDR prefix or a single IP address.
+// Like AddRule, it should be called during initialization.
+//
+// Built-in names are private, loopback, link_local, multicast, unspecified,
+// cloud_metadata and reserved.
+//
+// Example:
+//
+//	RegisterIPRange("office", "203.0.113.0/24", "2001:db8:42::/48")
+//
+//	type Webhook struct {
+//	    Target string `validator:"required url ip_not_in=office cloud_metadata"`
+//	}
+func RegisterIPRange(name string, cidrs ...string) error {
+	prefixes, err := parsePrefixes(cidrs)
+	if err != nil {
+		return err
+	}
+	ipRanges[name] = prefixes
+	return nil
+}
+
+func addIPRules() {
+	// Address routable on the public internet
+	addIPRule("ip_public", "must be a public IP address", func(addr netip.Addr) bool {
+		for _, name := range nonPublicRanges {
+			if inPrefixes(addr, ipRanges[name]) {
+				return false
+			}
+		}
+		return true
+	})
+
+	// RFC 1918 or unique local address
+	addIPRule("ip_private", "must be a private IP address", netip.Addr.IsPrivate)
+
+	// Loopback address, including localhost
+	addIPRule("ip_loopback", "must be a loopback IP address", netip.Addr.IsLoopback)
+
+	// Address within one of the listed CIDRs, addresses or named ranges
+	addListRule("ip_in", func(v reflect.Value, field reflect.StructField) []string {
+		param, _ := ruleParam(field, "ip_in")
+		prefixes, err := resolveIPRanges(param)
+		if err != nil {
+			return []string{"invalid ip_in: {param}"}
+		}
+		if v.Kind() != reflect.String || v.String() == "" {
+			return nil
+		}
+
+		addr, ok := hostAddr(v.String())
+		if !ok || !inPrefixes(addr, prefixes) {
+			return []string{"IP address must be in {param}"}
+		}
+		return nil
+	})
+
+	// Address outside all of the listed CIDRs, addresses or named ranges
+	addListRule("ip_not_in", func(v reflect.Value, field reflect.StructField) []string {
+		param, _ := ruleParam(field, "ip_not_in")
+		prefixes, err := resolveIPRanges(param)
+		if err != nil {
+			return []string{"invalid ip_not_in: {param}"}
+		}
+		if v.Kind() != reflect.String || v.String() == "" {
+			return nil
+		}
+
+		addr, ok := hostAddr(v.String())
+		if ok && inPrefixes(addr, prefixes) {
+			return []string{"IP address must not be in {param}"}
+		}
+		if !ok && !isResolvableHostName(hostOf(v.String())) {
+			return []string{"must be a valid IP address"}
+		}
+		return nil
+	})
+}
+
+// addIPRule registers a rule that checks the address held by non-empty
+// strings with valid. Host names other than localhost cannot be checked
+// without resolving them: they pass ip_public and fail the other rules.
+func addIPRule(name, msg string, valid func(netip.Addr) bool) {
+	AddRule(name, func(v reflect.Value, field reflect.StructField) []string {
+		if v.Kind() != reflect.String || v.String() == "" {
+			return nil
+		}
+
+		addr, ok := hostAddr(v.String())
+		switch {
+		case ok && !valid(addr):
+			return []string{msg}
+		case !ok && !isResolvableHostName(hostOf(v.String())):
+			return []string{"must be a valid IP address"}
+		case !ok && name != "ip_public":
+			return []string{msg}
+		}
+		return nil
+	})
+}
+
+// hostOf returns the host of a URL, of a host:port address, or s itself.
+func hostOf(s string) string {
+	if strings.Contains(s, "://") {
+		if u, err := url.Parse(s); err == nil {
+			return u.Hostname()
+		}
+	}
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		return host
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+}
+
+// hostAddr returns the IP address held by s, which may be an address, a
+// URL or a host:port. IPv6 addresses embedding an IPv4 address are reduced
+// to it (see embeddedIPv4), IPv4 addresses in the shorthand, decimal, octal
+// and hex forms accepted by URL parsers such as 127.1 and 0x7f000001 are
+// parsed, and localhost names map to the loopback address. No name
+// resolution is done.
+func hostAddr(s string) (netip.Addr, bool) {
+	host := hostOf(s)
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return embeddedIPv4(addr.WithZone("")), true
+	}
+	if addr, ok := parseIPv4Number(host); ok {
+		return addr, true
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return netip.IPv6Loopback(), true
+	}
+	return netip.Addr{}, false
+}
+
+// embeddedIPv4 returns the IPv4 address carried by an IPv4-mapped
+// (::ffff:0:0/96), IPv4-compatible (::/96), NAT64 (64:ff9b::/96) or 6to4
+// (2002::/16) address, and any other address unchanged. :: and ::1 are not
+// IPv4-compatible addresses.
+func embeddedIPv4(addr netip.Addr) netip.Addr {
+	if !addr.Is6() {
+		return addr
+	}
+	b := addr.As16()
+	switch {
+	case addr.Is4In6():
+		return addr.Unmap()
+	case nat64Prefix.Contains(addr), ipv4CompatiblePrefix.Contains(addr) && b[12] != 0:
+		return netip.AddrFrom4([4]byte(b[12:16]))
+	case sixToFourPrefix.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[2:6]))
+	}
+	return addr
+}
+
+var (
+	ipv4CompatiblePrefix = netip.MustParsePrefix("::/96")
+	nat64Prefix          = netip.MustParsePrefix("64:ff9b::/96")
+	sixToFourPrefix      = netip.MustParsePrefix("2002::/16")
+)
+
+// parseIPv4Number parses the IPv4 forms that URL parsers and inet_aton
+// accept besides dotted decimal: one to four parts in decimal, octal (0177)
+// or hex (0x7f), the last part filling the remaining bytes, as in 127.1 or
+// 2130706433.
+func parseIPv4Number(host string) (netip.Addr, bool) {
+	parts := strings.Split(strings.TrimSuffix(host, "."), ".")
+	if len(parts) > 4 {
+		return netip.Addr{}, false
+	}
+
+	var n uint64
+	for i, part := range parts {
+		base := 10
+		switch {
+		case len(part) > 2 && (part[:2] == "0x" || part[:2] == "0X"):
+			part, base = part[2:], 16
+		case len(part) > 1 && part[0] == '0':
+			part, base = part[1:], 8
+		}
+		x, err := strconv.ParseUint(part, base, 32)
+		if err != nil {
+			return netip.Addr{}, false
+		}
+
+		// Every part is one byte, except the last which fills the rest
+		bits := 8
+		if i == len(parts)-1 {
+			bits = 8 * (4 - i)
+		}
+		if x >= 1<<bits {
+			return netip.Addr{}, false
+		}
+		n = n<<bits | x
+	}
+
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(n))
+	return netip.AddrFrom4(b), true
+}
+
+// isResolvableHostName reports whether s is an RFC 1123 host name (see
+// isHostname) that could be resolved rather than read as an address. Names
+// whose last label is a number are not: URL parsers read them as IPv4
+// addresses, so they are rejected unless parseIPv4Number accepts them.
+func isResolvableHostName(s string) bool {
+	labels := strings.Split(strings.TrimSuffix(s, "."), ".")
+	last := strings.ToLower(labels[len(labels)-1])
+	if isDigits(last) || strings.HasPrefix(last, "0x") && isHexDigits(last[2:]) {
+		return false
+	}
+	return isHostname(s)
+}
+
+// resolveIPRanges parses a space separated list of CIDRs, addresses and
+// named ranges.
+func resolveIPRanges(param string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, item := range strings.Fields(param) {
+		if named, ok := ipRanges[item]; ok {
+			prefixes = append(prefixes, named...)
+			continue
+		}
+		parsed, err := parsePrefixes([]string{item})
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, parsed...)
+	}
+	if len(prefixes) == 0 {
+		return nil, fmt.Errorf("no IP ranges given")
+	}
+	return prefixes, nil
+}
+
+// parsePrefixes parses CIDR prefixes and single addresses.
+func parsePrefixes(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, c := range cidrs {
+		if addr, err := netip.ParseAddr(c); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(c)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+func mustPrefixes(cidrs ...string) []netip.Prefix {
+	prefixes, err := parsePrefixes(cidrs)
+	if err != nil {
+		panic(err)
+	}
+	return prefixes
+}
+
+func inPrefixes(addr netip.Addr, prefixes []netip.Prefix) bool {
+	for _, p := range prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func isHexDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !isDigit(c) && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+			return false
+		}
+	}
+	return true
+}
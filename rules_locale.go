@@ -0,0 +1,255 @@
+package goverify
+
+import (
+	_ "embed"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	//go:embed data/iso3166_1.txt
+	iso3166Table []byte
+	//go:embed data/iso3166_2.txt
+	iso3166SubdivisionTable []byte
+	//go:embed data/iso639_1.txt
+	iso639Table []byte
+
+	// countries indexes every country by its alpha-2, alpha-3 and numeric
+	// codes.
+	countries = make(map[string]*Country)
+	// subdivisions maps ISO 3166-2 codes to subdivision names.
+	subdivisions = make(map[string]string)
+	// languages maps ISO 639-1 codes to language names.
+	languages = make(map[string]string)
+)
+
+// irregularLanguageTags are the grandfathered BCP 47 tags that do not
+// follow the tag syntax.
+var irregularLanguageTags = map[string]bool{
+	"en-gb-oed": true, "i-ami": true, "i-bnn": true, "i-default": true, "i-enochian": true,
+	"i-hak": true, "i-klingon": true, "i-lux": true, "i-mingo": true, "i-navajo": true,
+	"i-pwn": true, "i-tao": true, "i-tay": true, "i-tsu": true,
+	"sgn-be-fr": true, "sgn-be-nl": true, "sgn-ch-de": true,
+}
+
+func addLocaleRules() {
+	for _, row := range loadTable(iso3166Table) {
+		c := &Country{Alpha2: row[0], Alpha3: row[1], Numeric: row[2], Name: row[3]}
+		countries[c.Alpha2], countries[c.Alpha3], countries[c.Numeric] = c, c, c
+	}
+	for _, row := range loadTable(iso3166SubdivisionTable) {
+		subdivisions[row[0]] = row[1]
+	}
+	for _, row := range loadTable(iso639Table) {
+		languages[row[0]] = row[2]
+	}
+
+	// Uppercase ISO 3166-1 alpha-2 country code such as US
+	addStringRule("iso3166_alpha2", "must be a valid ISO 3166-1 alpha-2 country code", func(s string) bool {
+		c, ok := countries[s]
+		return ok && c.Alpha2 == s
+	})
+
+	// Uppercase ISO 3166-1 alpha-3 country code such as USA
+	addStringRule("iso3166_alpha3", "must be a valid ISO 3166-1 alpha-3 country code", func(s string) bool {
+		c, ok := countries[s]
+		return ok && c.Alpha3 == s
+	})
+
+	// ISO 3166-1 numeric country code, as a three digit string (840) or an
+	// integer
+	AddRule("iso3166_numeric", func(v reflect.Value, field reflect.StructField) []string {
+		var code string
+		switch v.Kind() {
+		case reflect.String:
+			if code = v.String(); code == "" {
+				return nil
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			code = leftPad(strconv.FormatInt(v.Int(), 10), 3)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			code = leftPad(strconv.FormatUint(v.Uint(), 10), 3)
+		default:
+			return nil
+		}
+
+		if c, ok := countries[code]; !ok || c.Numeric != code {
+			return []string{"must be a valid ISO 3166-1 numeric country code"}
+		}
+		return nil
+	})
+
+	// Lowercase ISO 639-1 language code such as en
+	addStringRule("iso639_1", "must be a valid ISO 639-1 language code", func(s string) bool {
+		_, ok := languages[s]
+		return ok
+	})
+
+	// Well-formed BCP 47 language tag such as en-US or zh-Hant-TW
+	addStringRule("bcp47", "must be a valid BCP 47 language tag", isLanguageTag)
+
+	// ISO 3166-2 subdivision code such as US-CA
+	addStringRule("iso3166_2", "must be a valid ISO 3166-2 subdivision code", func(s string) bool {
+		_, ok := subdivisions[s]
+		return ok
+	})
+}
+
+// LookupCountry returns the ISO 3166-1 country with the given alpha-2,
+// alpha-3 or numeric code, ignoring case.
+//
+// Example:
+//
+//	c, ok := LookupCountry("deu")
+//	// c.Alpha2 == "DE", c.Numeric == "276", c.Name == "Germany"
+func LookupCountry(code string) (Country, bool) {
+	c, ok := countries[strings.ToUpper(code)]
+	if !ok {
+		return Country{}, false
+	}
+	return *c, true
+}
+
+// LanguageName returns the English name of an ISO 639-1 language code.
+func LanguageName(code string) (string, bool) {
+	name, ok := languages[strings.ToLower(code)]
+	return name, ok
+}
+
+// SubdivisionName returns the name of an ISO 3166-2 subdivision code such
+// as US-CA.
+func SubdivisionName(code string) (string, bool) {
+	name, ok := subdivisions[strings.ToUpper(code)]
+	return name, ok
+}
+
+// isLanguageTag reports whether s is a well-formed BCP 47 (RFC 5646)
+// language tag. Subtags are checked structurally, except two letter
+// primary languages, which must be ISO 639-1 codes. Duplicate variants and
+// extension singletons are rejected.
+func isLanguageTag(s string) bool {
+	tag := strings.ToLower(s)
+	if irregularLanguageTags[tag] {
+		return true
+	}
+
+	subtags := strings.Split(tag, "-")
+	for _, st := range subtags {
+		if len(st) == 0 || len(st) > 8 || !isLowerAlnum(st) {
+			return false
+		}
+	}
+	if subtags[0] == "x" {
+		return len(subtags) > 1
+	}
+
+	// Primary language, with up to three extended language subtags
+	i := 0
+	lang := subtags[i]
+	switch {
+	case len(lang) == 2 || len(lang) == 3:
+		if !isLowerAlpha(lang) {
+			return false
+		}
+		if _, ok := languages[lang]; len(lang) == 2 && !ok {
+			return false
+		}
+		i++
+		for n := 0; n < 3 && i < len(subtags) && len(subtags[i]) == 3 && isLowerAlpha(subtags[i]); n++ {
+			i++
+		}
+	case len(lang) >= 4:
+		if !isLowerAlpha(lang) {
+			return false
+		}
+		i++
+	default:
+		return false
+	}
+
+	// Script
+	if i < len(subtags) && len(subtags[i]) == 4 && isLowerAlpha(subtags[i]) {
+		i++
+	}
+
+	// Region
+	if i < len(subtags) {
+		if st := subtags[i]; (len(st) == 2 && isLowerAlpha(st)) || (len(st) == 3 && isDigits(st)) {
+			i++
+		}
+	}
+
+	// Variants
+	seen := make(map[string]bool)
+	for ; i < len(subtags); i++ {
+		st := subtags[i]
+		if !(len(st) >= 5 || (len(st) == 4 && isDigit(st[0]))) {
+			break
+		}
+		if seen[st] {
+			return false
+		}
+		seen[st] = true
+	}
+
+	// Extensions and private use
+	for i < len(subtags) {
+		singleton := subtags[i]
+		if len(singleton) != 1 || seen[singleton] {
+			return false
+		}
+		seen[singleton] = true
+		i++
+
+		start := i
+		minLen := 2
+		if singleton == "x" {
+			minLen = 1
+		}
+		for i < len(subtags) && len(subtags[i]) >= minLen {
+			i++
+		}
+		if i == start {
+			return false
+		}
+		if singleton == "x" {
+			return i == len(subtags)
+		}
+	}
+	return true
+}
+
+func isLowerAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isLowerAlnum(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) && (s[i] < 'a' || s[i] > 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+func leftPad(s string, n int) string {
+	if len(s) >= n {
+		return s
+	}
+	return strings.Repeat("0", n-len(s)) + s
+}
@@ -0,0 +1,180 @@
+package goverify
+
+import (
+	_ "embed"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/phone_plans.txt
+var phonePlanTable []byte
+
+// phonePlan is the numbering plan of a region.
+type phonePlan struct {
+	region      string
+	callingCode string
+	lengths     []int
+	trunkPrefix string
+}
+
+var (
+	// phonePlans maps regions to their numbering plans.
+	phonePlans = make(map[string]*phonePlan)
+	// callingCodes maps country calling codes to the plans sharing them.
+	callingCodes = make(map[string][]*phonePlan)
+)
+
+// phonePunctuation is removed from phone numbers before they are checked.
+var phonePunctuation = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "/", "", "\u00a0", "")
+
+func addPhoneRules() {
+	for _, row := range loadTable(phonePlanTable) {
+		p := &phonePlan{region: row[0], callingCode: row[1], lengths: parseLengths(row[2]), trunkPrefix: row[3]}
+		if p.trunkPrefix == "-" {
+			p.trunkPrefix = ""
+		}
+		phonePlans[p.region] = p
+		callingCodes[p.callingCode] = append(callingCodes[p.callingCode], p)
+	}
+
+	// E.164 number: + and up to 15 digits. Numbers of calling codes with a
+	// known numbering plan must have one of its lengths.
+	addStringRule("e164", "must be a valid E.164 phone number", isE164)
+
+	// Phone number of one of the listed regions (phone=US CA), in national
+	// or international format, punctuation allowed
+	addListRule("phone", func(v reflect.Value, field reflect.StructField) []string {
+		param, _ := ruleParam(field, "phone")
+		regions := strings.Fields(strings.ToUpper(param))
+		for _, r := range regions {
+			if phonePlans[r] == nil {
+				return []string{"invalid phone: {param}"}
+			}
+		}
+		if len(regions) == 0 {
+			return []string{"invalid phone: {param}"}
+		}
+		if v.Kind() != reflect.String || v.String() == "" {
+			return nil
+		}
+
+		for _, r := range regions {
+			if number, ok := toE164(v.String(), r); ok && strings.HasPrefix(number, "+"+phonePlans[r].callingCode) && isE164(number) {
+				return nil
+			}
+		}
+		return []string{"must be a valid {param} phone number"}
+	})
+}
+
+func addPhoneTransformers() {
+	// E.164 form of a phone number. National numbers get the calling code of
+	// the region named by the parameter: the value of the sibling field of
+	// that name (phone_e164=Country), or a region code (phone_e164=US). A
+	// region after a colon is used when the sibling field is empty
+	// (phone_e164=Country:US); without one, a national number fails.
+	AddStructTransformer("phone_e164", func(v reflect.Value, param string, parent reflect.Value) error {
+		if v.Kind() != reflect.String || v.String() == "" {
+			return nil
+		}
+
+		name, fallback, _ := strings.Cut(param, ":")
+		region, ok := siblingString(parent, name)
+		switch {
+		case !ok:
+			region = name
+		case strings.TrimSpace(region) == "":
+			region = fallback
+		}
+		region = strings.ToUpper(strings.TrimSpace(region))
+
+		number, ok := toE164(v.String(), region)
+		if !ok && region == "" {
+			return fmt.Errorf("phone number must start with + or 00 when %s is empty", name)
+		}
+		if ok {
+			v.SetString(number)
+		}
+		return nil
+	})
+}
+
+// toE164 converts a phone number to E.164 form, removing punctuation. A
+// number starting with + or 00 is international; any other number is
+// national to region and loses its trunk prefix, unless the number is
+// only valid with it.
+func toE164(number, region string) (string, bool) {
+	digits := phonePunctuation.Replace(strings.TrimSpace(number))
+	switch {
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	case strings.HasPrefix(digits, "00"):
+		digits = digits[2:]
+	default:
+		plan := phonePlans[region]
+		if plan == nil {
+			return "", false
+		}
+		if national, ok := strings.CutPrefix(digits, plan.trunkPrefix); ok && plan.trunkPrefix != "" && slices.Contains(plan.lengths, len(national)) {
+			digits = national
+		}
+		digits = plan.callingCode + digits
+	}
+
+	if digits == "" || !isDigits(digits) {
+		return "", false
+	}
+	return "+" + digits, true
+}
+
+// isE164 reports whether s is + followed by up to 15 digits with a
+// non-zero first digit, and for calling codes in the numbering plan table,
+// a national number of a valid length.
+func isE164(s string) bool {
+	digits, ok := strings.CutPrefix(s, "+")
+	if !ok || len(digits) < 2 || len(digits) > 15 || digits[0] == '0' || !isDigits(digits) {
+		return false
+	}
+
+	// Calling codes are prefix-free, so at most one of these matches
+	for n := 1; n <= 3 && n < len(digits); n++ {
+		plans, known := callingCodes[digits[:n]]
+		if !known {
+			continue
+		}
+		national := digits[n:]
+		for _, p := range plans {
+			if slices.Contains(p.lengths, len(national)) && (p.callingCode != "1" || isNANPNumber(national)) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// isNANPNumber reports whether a ten digit North American number has an
+// area code and exchange that do not start with 0 or 1.
+func isNANPNumber(national string) bool {
+	return national[0] >= '2' && national[3] >= '2'
+}
+
+// parseLengths parses a comma separated list of lengths and min-max ranges.
+func parseLengths(s string) []int {
+	var lengths []int
+	for _, part := range strings.Split(s, ",") {
+		lo, hi, isRange := strings.Cut(part, "-")
+		min, _ := strconv.Atoi(lo)
+		max := min
+		if isRange {
+			max, _ = strconv.Atoi(hi)
+		}
+		for n := min; n <= max; n++ {
+			lengths = append(lengths, n)
+		}
+	}
+	return lengths
+}
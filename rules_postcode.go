@@ -0,0 +1,69 @@
+package goverify
+
+import (
+	_ "embed"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+//go:embed data/postcodes.txt
+var postcodeTable []byte
+
+// postcodePatterns maps regions to the pattern of their postal codes.
+var postcodePatterns = make(map[string]*regexp.Regexp)
+
+func addPostcodeRules() {
+	for _, row := range loadTable(postcodeTable) {
+		postcodePatterns[row[0]] = regexp.MustCompile(`^(?:` + row[1] + `)$`)
+	}
+
+	// Postal code of the given region (postcode=DE)
+	AddRule("postcode", func(v reflect.Value, field reflect.StructField) []string {
+		param, _ := ruleParam(field, "postcode")
+		if _, ok := postcodePatterns[strings.ToUpper(param)]; !ok {
+			return []string{"invalid postcode: {param}"}
+		}
+		if v.Kind() != reflect.String || v.String() == "" {
+			return nil
+		}
+
+		if !IsPostcode(v.String(), param) {
+			return []string{"must be a valid {param} postal code"}
+		}
+		return nil
+	})
+
+	// Postal code of the region held by a sibling country field
+	// (postcode_iso3166_field=Country). Regions without a known format pass,
+	// as does an empty or nil country.
+	AddStructRule("postcode_iso3166_field", func(v reflect.Value, field reflect.StructField, parent reflect.Value) []string {
+		param, _ := ruleParam(field, "postcode_iso3166_field")
+		region, ok := siblingString(parent, param)
+		if !ok {
+			return []string{"invalid postcode_iso3166_field: {param}"}
+		}
+		region = strings.ToUpper(strings.TrimSpace(region))
+		if v.Kind() != reflect.String || v.String() == "" || region == "" {
+			return nil
+		}
+
+		if _, known := postcodePatterns[region]; known && !IsPostcode(v.String(), region) {
+			return []string{"must be a valid postal code"}
+		}
+		return nil
+	})
+}
+
+// IsPostcode reports whether code is a postal code of region, an ISO 3166-1
+// alpha-2 code. The check ignores case and surrounding spaces, and reports
+// false for regions without a known format.
+//
+// Example:
+//
+//	IsPostcode("SW1A 1AA", "GB") // true
+//	IsPostcode("1234", "DE")     // false
+func IsPostcode(code, region string) bool {
+	pattern, ok := postcodePatterns[strings.ToUpper(strings.TrimSpace(region))]
+	return ok && pattern.MatchString(strings.ToUpper(strings.TrimSpace(code)))
+}
@@ -7,6 +7,7 @@ import (
 )
 
 var transformers = map[string]TransformFunc{}
+var structTransformers = map[string]StructTransformFunc{}
 var priorityLookup = map[string]int{
 	"trim":              1,
 	"remove_whitespace": 2,
@@ -16,6 +17,8 @@ var priorityLookup = map[string]int{
 
 func init() {
 	addStringTransformers()
+	addIDTransformers()
+	addPhoneTransformers()
 }
 
 // Transform applies transformations to a struct according to its field tags.
@@ -37,7 +40,9 @@ func init() {
 //	if err != nil {
 //	    log.Printf("Transform failed: %v", err)
 //	}
-func Transform(dto interface{}) error {
+func Transform(dto interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
 	if dto == nil {
 		return NewErr("invalid payload", nil)
 	}
@@ -51,7 +56,11 @@ func Transform(dto interface{}) error {
 		return NewErr("input must be a struct", nil)
 	}
 
-	return transformStruct(val)
+	if violations := transformStruct(val, "", ""); len(violations) > 0 {
+		return newViolationErr("transformation failed", violations, o.locale)
+	}
+
+	return nil
 }
 
 // AddTransformer adds a new transformation function that can be referenced in struct tags.
@@ -78,34 +87,51 @@ func AddTransformer(name string, fn TransformFunc) {
 	transformers[name] = fn
 }
 
-func transformStruct(val reflect.Value) error {
+// AddStructTransformer adds a transformation that receives the parameter
+// given in the transform tag and the struct holding the field.
+//
+// Example:
+//
+//	// Prefix a value with the code held by a sibling field
+//	AddStructTransformer("prefix_from", func(v reflect.Value, param string, parent reflect.Value) error {
+//	    if code := parent.FieldByName(param); code.Kind() == reflect.String && v.Kind() == reflect.String {
+//	        v.SetString(code.String() + "-" + v.String())
+//	    }
+//	    return nil
+//	})
+//
+//	type Ticket struct {
+//	    Project string
+//	    Key     string `transform:"prefix_from=Project"`
+//	}
+func AddStructTransformer(name string, fn StructTransformFunc) {
+	structTransformers[name] = fn
+}
+
+// transformStruct applies the transform tags of every field of val and its
+// nested structs, returning one violation per failed field.
+func transformStruct(val reflect.Value, prefix, pointer string) []Violation {
 	t := val.Type()
-	violations := make(map[string][]string)
+	var violations []Violation
 
 	for i := 0; i < val.NumField(); i++ {
 		field := t.Field(i)
 		fieldVal := val.Field(i)
+		path := joinPath(prefix, field.Name)
+		ptr := pointer + "/" + escapePointer(jsonName(field))
 
 		// Handle nested structs
 		if fieldVal.Kind() == reflect.Struct {
-			if err := transformStruct(fieldVal); err != nil {
-				if vErr, ok := err.(*Err); ok {
-					for k, v := range vErr.Fields {
-						violations[field.Name+"."+k] = v
-					}
-				}
+			if nested := transformStruct(fieldVal, path, ptr); len(nested) > 0 {
+				violations = append(violations, nested...)
 				continue
 			}
 		}
 
 		// Handle pointers to structs
 		if fieldVal.Kind() == reflect.Ptr && !fieldVal.IsNil() && fieldVal.Elem().Kind() == reflect.Struct {
-			if err := transformStruct(fieldVal.Elem()); err != nil {
-				if vErr, ok := err.(*Err); ok {
-					for k, v := range vErr.Fields {
-						violations[field.Name+"."+k] = v
-					}
-				}
+			if nested := transformStruct(fieldVal.Elem(), path, ptr); len(nested) > 0 {
+				violations = append(violations, nested...)
 				continue
 			}
 		}
@@ -116,31 +142,30 @@ func transformStruct(val reflect.Value) error {
 			for j := 0; j < fieldVal.Len(); j++ {
 				elem := fieldVal.Index(j)
 				if elem.Kind() == reflect.Struct {
-					if err := transformStruct(elem); err != nil {
-						if vErr, ok := err.(*Err); ok {
-							for k, v := range vErr.Fields {
-								violations[fmt.Sprintf("%s[%d].%s", field.Name, j, k)] = v
-							}
-						}
-					}
+					violations = append(violations, transformStruct(elem, fmt.Sprintf("%s[%d]", path, j), fmt.Sprintf("%s/%d", ptr, j))...)
 				}
 			}
 		}
 
 		// Apply transformations to the field
-		if err := applyTransformations(fieldVal, field); err != nil {
-			violations[field.Name] = append(violations[field.Name], err.Error())
+		if err := applyTransformations(fieldVal, field, val); err != nil {
+			violations = append(violations, Violation{
+				Field:    path,
+				Pointer:  ptr,
+				Message:  err.Error(),
+				template: err.Error(),
+				label:    field.Name,
+				custom:   true,
+			})
 		}
 	}
 
-	if len(violations) > 0 {
-		return NewErr("transformation failed", violations)
-	}
-
-	return nil
+	return violations
 }
 
-func applyTransformations(v reflect.Value, field reflect.StructField) error {
+// applyTransformations runs the transformations named in the transform tag
+// of field on v. parent is the struct holding the field.
+func applyTransformations(v reflect.Value, field reflect.StructField, parent reflect.Value) error {
 	if !v.CanSet() {
 		return nil
 	}
@@ -154,10 +179,15 @@ func applyTransformations(v reflect.Value, field reflect.StructField) error {
 	orderedTransforms := orderTransforms(transforms)
 
 	for _, t := range orderedTransforms {
-		if fn, exists := transformers[t]; exists {
+		name, param, _ := strings.Cut(t, "=")
+		if fn, exists := transformers[name]; exists {
 			if err := fn(v); err != nil {
 				return err
 			}
+		} else if fn, exists := structTransformers[name]; exists {
+			if err := fn(v, param, parent); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -8,19 +8,88 @@ type (
 	// If the validation passes, it returns an empty slice.
 	ValidationRule func(v reflect.Value, field reflect.StructField) []string
 
+	// StructRule is a ValidationRule that also receives the struct holding
+	// the field, for rules that depend on sibling fields.
+	StructRule func(v reflect.Value, field reflect.StructField, parent reflect.Value) []string
+
 	// TransformFunc is a function type that transforms a field value.
 	// It takes a reflect.Value as input and returns an error if the transformation fails.
 	// If the transformation succeeds, it returns nil.
 	TransformFunc func(reflect.Value) error
 
+	// StructTransformFunc is a transformation that also receives the
+	// parameter given in the transform tag (phone_e164=US) and the struct
+	// holding the field, so it can depend on sibling fields.
+	StructTransformFunc func(v reflect.Value, param string, parent reflect.Value) error
+
 	// Err represents a validation or transformation error.
 	// It contains a message and a map of field-specific error messages.
+	// Violations holds the rule-level details behind Fields and is used to
+	// re-render messages in another locale.
 	Err struct {
-		Msg    string              `json:"message"`
-		Fields map[string][]string `json:"fields,omitempty"`
+		Msg        string              `json:"message"`
+		Fields     map[string][]string `json:"fields,omitempty"`
+		Violations []Violation         `json:"-"`
+
+		msgID      string
+		status     int
+		validation bool
+	}
+
+	// Violation describes a single failed rule on a field.
+	// Field is the Go-style path (Items[0].Email) and Pointer the RFC 6901
+	// JSON pointer built from the json tag names (/items/0/email). Line and
+	// Column locate the offending value in the source document when the
+	// violation comes from ValidateJSON, and are zero otherwise.
+	// Message is the rendered text; the unexported fields keep what is needed
+	// to render it again for a different locale.
+	Violation struct {
+		Field   string `json:"field"`
+		Pointer string `json:"pointer,omitempty"`
+		Line    int    `json:"line,omitempty"`
+		Column  int    `json:"column,omitempty"`
+		Rule    string `json:"rule,omitempty"`
+		Param   string `json:"param,omitempty"`
+		Message string `json:"message"`
+
+		template string
+		label    string
+		value    interface{}
+		custom   bool
+	}
+
+	// Country is an ISO 3166-1 country, as returned by LookupCountry.
+	Country struct {
+		Alpha2  string `json:"alpha2"`
+		Alpha3  string `json:"alpha3"`
+		Numeric string `json:"numeric"`
+		Name    string `json:"name"`
+	}
+
+	// Catalog maps message templates (or rule names) to localized templates.
+	// Templates may use the {field}, {param} and {value} placeholders.
+	Catalog map[string]string
+
+	// Option configures optional behaviour of Validate and related functions.
+	Option func(*options)
+
+	options struct {
+		locale                string
+		maxBodySize           int64
+		disallowUnknownFields bool
+		envPrefix             string
+		envLookup             func(string) (string, bool)
+	}
+
+	ruleSpec struct {
+		name  string
+		param string
 	}
 
 	validator struct {
-		rules map[string]ValidationRule
+		rules       map[string]ValidationRule
+		structRules map[string]StructRule
+		// listRules names the rules whose parameter is a space separated list
+		listRules map[string]bool
 	}
 )
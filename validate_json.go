@@ -0,0 +1,184 @@
+package goverify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateJSON decodes data into dst, runs Transform and Validate, and sets
+// the Line and Column of every violation to the position of the offending
+// value in data. Violations on keys that are missing from the document point
+// at the enclosing object. Decoding errors are reported like Bind does, with
+// syntax errors positioned at the invalid character.
+//
+// Example:
+//
+//	data, _ := os.ReadFile("servers.json")
+//	var cfg Config
+//	if err := ValidateJSON(data, &cfg); err != nil {
+//	    fmt.Fprintln(os.Stderr, ToTextErr(err))
+//	    // validation failed
+//	    //   Servers[3].Port (line 18, column 15): must be at least 1024
+//	}
+func ValidateJSON(data []byte, dst interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	if err := decodeJSONDocument(data, dst, o); err != nil {
+		return err
+	}
+	if err := Transform(dst, opts...); err != nil {
+		return attachPositions(err, data)
+	}
+	if _, err := Validate(dst, opts...); err != nil {
+		return attachPositions(err, data)
+	}
+	return nil
+}
+
+// decodeJSONDocument decodes data into dst, returning decoding errors with
+// the position of the offending value.
+func decodeJSONDocument(data []byte, dst interface{}, o *options) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if o.disallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(dst); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			vl := bindViolation("", "", ruleSpec{name: "syntax"}, "invalid JSON syntax")
+			vl.Line, vl.Column = lineColumn(data, max(int(syntaxErr.Offset)-1, 0))
+			return newStatusErr(http.StatusBadRequest, "malformed JSON", []Violation{vl}, o.locale)
+		}
+		return attachPositions(jsonDecodeErr(err, dst, o.locale), data)
+	}
+	return nil
+}
+
+// attachPositions sets the line and column of the violations of err from the
+// JSON pointers they carry.
+func attachPositions(err error, data []byte) error {
+	e, ok := err.(*Err)
+	if !ok || len(e.Violations) == 0 {
+		return err
+	}
+
+	idx := indexJSON(data)
+	for i, vl := range e.Violations {
+		if off, found := idx.offset(vl.Pointer); found {
+			e.Violations[i].Line, e.Violations[i].Column = lineColumn(data, off)
+		}
+	}
+	return e
+}
+
+// jsonIndex records where the values of a JSON document start.
+type jsonIndex struct {
+	// offsets maps the JSON pointer of every value to its byte offset.
+	offsets map[string]int
+	// keys lists the keys of every object, by pointer, in document order.
+	keys map[string][]string
+}
+
+// indexJSON indexes every value in data. Values after a syntax error are not
+// indexed.
+func indexJSON(data []byte) jsonIndex {
+	idx := jsonIndex{offsets: make(map[string]int), keys: make(map[string][]string)}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	idx.indexValue(dec, data, "")
+	return idx
+}
+
+// offset returns the offset of the value at pointer, or of its closest
+// ancestor in the document. Object keys are matched like encoding/json
+// matches them to fields: exactly, or else case-insensitively, the last such
+// key winning.
+func (idx jsonIndex) offset(pointer string) (int, bool) {
+	cur := ""
+	off, found := idx.offsets[cur]
+	if pointer == "" {
+		return off, found
+	}
+
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	for _, seg := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		next := cur + "/" + seg
+		if _, ok := idx.offsets[next]; !ok {
+			name := unescape.Replace(seg)
+			next = ""
+			for _, key := range idx.keys[cur] {
+				if strings.EqualFold(key, name) {
+					next = cur + "/" + escapePointer(key)
+				}
+			}
+			if next == "" {
+				break
+			}
+		}
+		cur = next
+		off = idx.offsets[cur]
+	}
+	return off, found
+}
+
+func (idx jsonIndex) indexValue(dec *json.Decoder, data []byte, pointer string) error {
+	idx.offsets[pointer] = skipSeparators(data, int(dec.InputOffset()))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			name, _ := key.(string)
+			idx.keys[pointer] = append(idx.keys[pointer], name)
+			if err := idx.indexValue(dec, data, pointer+"/"+escapePointer(name)); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			if err := idx.indexValue(dec, data, pointer+"/"+strconv.Itoa(i)); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	}
+	return err
+}
+
+// skipSeparators advances off past whitespace, colons and commas, to the
+// first byte of the next value.
+func skipSeparators(data []byte, off int) int {
+	for off < len(data) {
+		switch data[off] {
+		case ' ', '\t', '\r', '\n', ':', ',':
+			off++
+		default:
+			return off
+		}
+	}
+	return off
+}
+
+// lineColumn converts a byte offset into 1-based line and column numbers,
+// counting columns in characters.
+func lineColumn(data []byte, off int) (int, int) {
+	if off > len(data) {
+		off = len(data)
+	}
+	line := 1 + bytes.Count(data[:off], []byte("\n"))
+	lineStart := bytes.LastIndexByte(data[:off], '\n') + 1
+	return line, 1 + utf8.RuneCount(data[lineStart:off])
+}
@@ -3,12 +3,15 @@
 package goverify
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 )
 
 var v = &validator{
-	rules: make(map[string]ValidationRule),
+	rules:       make(map[string]ValidationRule),
+	structRules: make(map[string]StructRule),
+	listRules:   make(map[string]bool),
 }
 
 func init() {
@@ -16,13 +19,26 @@ func init() {
 	addSizeRules()
 	addRangeRules()
 	addPatternRules()
+	addEmailRules()
+	addDisposableRules()
 	addStringRules()
 	addNetworkRules()
+	addIPRules()
+	addIDRules()
+	addFinanceRules()
+	addLocaleRules()
+	addPhoneRules()
+	addPostcodeRules()
 	addCustomStringRules()
 	addDateTimeRules()
+	addFileRules()
 }
 
 // Validate validates a struct according to its field tags.
+// Exported fields holding structs, non-nil pointers to structs, and slices of
+// structs or of pointers to structs are validated too, at any depth, with
+// their errors reported under paths such as Items[0].Address.City. time.Time
+// fields are not descended into.
 // It returns true if validation passes, false and an error otherwise.
 //
 // Example:
@@ -43,7 +59,18 @@ func init() {
 //	if !valid {
 //	    log.Printf("Validation failed: %v", err)
 //	}
-func Validate(dto interface{}) (bool, error) {
+//
+// Messages can be rendered in another locale with WithLocale, and customized
+// per field with the msg and label tags:
+//
+//	type Signup struct {
+//	    Nick string `validator:"required min=3" label:"Nickname" msg:"min={field} must have {param}+ characters"`
+//	}
+//
+//	valid, err := Validate(signup, WithLocale("es"))
+func Validate(dto interface{}, opts ...Option) (bool, error) {
+	o := newOptions(opts)
+
 	if dto == nil {
 		return false, NewErr("invalid payload", nil)
 	}
@@ -57,34 +84,78 @@ func Validate(dto interface{}) (bool, error) {
 		return false, NewErr("input must be a struct", nil)
 	}
 
-	violations := make(map[string][]string)
+	if violations := validateStruct(val, "", ""); len(violations) > 0 {
+		return false, newViolationErr("validation failed", violations, o.locale)
+	}
+
+	return true, nil
+}
+
+// validateStruct applies the validator tags of every field of val, descending
+// into the nested structs of exported fields (see nestedStructs). Paths and
+// JSON pointers are reported relative to prefix and pointer.
+func validateStruct(val reflect.Value, prefix, pointer string) []Violation {
+	var violations []Violation
 	t := val.Type()
 
 	for i := 0; i < val.NumField(); i++ {
 		field := t.Field(i)
 		fieldVal := val.Field(i)
+		path := joinPath(prefix, field.Name)
+		ptr := pointer + "/" + escapePointer(jsonName(field))
 
-		validateTag := field.Tag.Get("validator")
-		if validateTag == "" {
-			continue
+		for _, rule := range parseRules(field.Tag.Get("validator")) {
+			var msgs []string
+			if ruleFunc, exists := v.rules[rule.name]; exists {
+				msgs = ruleFunc(fieldVal, field)
+			} else if ruleFunc, exists := v.structRules[rule.name]; exists {
+				msgs = ruleFunc(fieldVal, field, val)
+			}
+			for _, msg := range msgs {
+				violations = append(violations, newViolation(path, ptr, field, rule, msg, fieldVal))
+			}
 		}
 
-		rules := strings.Fields(validateTag)
-		for _, rule := range rules {
-			ruleName := strings.Split(rule, "=")[0]
-			if ruleFunc, exists := v.rules[ruleName]; exists {
-				if errs := ruleFunc(fieldVal, field); len(errs) > 0 {
-					violations[field.Name] = append(violations[field.Name], errs...)
-				}
+		if field.IsExported() {
+			for _, n := range nestedStructs(fieldVal, path, ptr) {
+				violations = append(violations, validateStruct(n.val, n.path, n.pointer)...)
 			}
 		}
 	}
 
-	if len(violations) > 0 {
-		return false, NewErr("validation failed", violations)
-	}
+	return violations
+}
 
-	return true, nil
+// nestedStruct is a struct reached from a field, with its path and pointer.
+type nestedStruct struct {
+	val           reflect.Value
+	path, pointer string
+}
+
+// nestedStructs returns the structs held by v: v itself, the target of a
+// non-nil pointer, or the struct and non-nil pointer elements of a slice.
+// time.Time values are left out.
+func nestedStructs(v reflect.Value, path, pointer string) []nestedStruct {
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch {
+	case v.Kind() == reflect.Struct && v.Type() != timeType:
+		return []nestedStruct{{v, path, pointer}}
+	case v.Kind() == reflect.Slice:
+		var out []nestedStruct
+		for j := 0; j < v.Len(); j++ {
+			elem := v.Index(j)
+			if elem.Kind() == reflect.Ptr && !elem.IsNil() {
+				elem = elem.Elem()
+			}
+			if elem.Kind() == reflect.Struct && elem.Type() != timeType {
+				out = append(out, nestedStruct{elem, fmt.Sprintf("%s[%d]", path, j), fmt.Sprintf("%s/%d", pointer, j)})
+			}
+		}
+		return out
+	}
+	return nil
 }
 
 // AddRule adds a new validation rule that can be referenced in struct tags.
@@ -93,23 +164,134 @@ func Validate(dto interface{}) (bool, error) {
 // Example:
 //
 //	// Add a custom validation rule
-//	AddRule("uuid", func(v reflect.Value, field reflect.StructField) []string {
+//	AddRule("sku", func(v reflect.Value, field reflect.StructField) []string {
 //	    if v.Kind() != reflect.String {
 //	        return nil
 //	    }
-//	    if !isValidUUID(v.String()) {
-//	        return []string{"must be a valid UUID"}
+//	    if !skuPattern.MatchString(v.String()) {
+//	        return []string{"must be a valid SKU"}
 //	    }
 //	    return nil
 //	})
 //
-//	type Resource struct {
-//	    ID string `validator:"required uuid"`
+//	type Product struct {
+//	    SKU string `validator:"required sku"`
 //	}
 func AddRule(key string, rule ValidationRule) {
 	v.rules[key] = rule
 }
 
+// AddStructRule adds a validation rule that also receives the struct holding
+// the field, so it can depend on sibling fields.
+//
+// Example:
+//
+//	// The field must be later than the sibling Start field
+//	AddStructRule("after_start", func(v reflect.Value, field reflect.StructField, parent reflect.Value) []string {
+//	    end, ok := v.Interface().(time.Time)
+//	    start, _ := parent.FieldByName("Start").Interface().(time.Time)
+//	    if ok && !end.After(start) {
+//	        return []string{"must be after the start time"}
+//	    }
+//	    return nil
+//	})
+//
+//	type Booking struct {
+//	    Start time.Time
+//	    End   time.Time `validator:"after_start"`
+//	}
+func AddStructRule(key string, rule StructRule) {
+	v.structRules[key] = rule
+}
+
+// addListRule adds a rule whose parameter is a space separated list of values
+// (see parseRules).
+func addListRule(key string, rule ValidationRule) {
+	AddRule(key, rule)
+	v.listRules[key] = true
+}
+
+// parseRules splits a validator tag into rule names and their parameters.
+// The parameter of a list rule (see addListRule) may hold several space
+// separated values: tokens that follow its rule=param token and are neither
+// registered rules nor rule=param pairs are appended to that parameter, so
+// `mime=image/png image/jpeg required` yields mime with "image/png
+// image/jpeg" and required. After any other rule such tokens are rule names
+// of their own.
+func parseRules(tag string) []ruleSpec {
+	var specs []ruleSpec
+	for _, token := range strings.Fields(tag) {
+		name, param, hasParam := strings.Cut(token, "=")
+		if last := len(specs) - 1; !hasParam && last >= 0 && specs[last].param != "" && v.listRules[specs[last].name] && !isRule(name) {
+			specs[last].param += " " + token
+			continue
+		}
+		specs = append(specs, ruleSpec{name: name, param: param})
+	}
+	return specs
+}
+
+// isRule reports whether name is a registered rule.
+func isRule(name string) bool {
+	_, ok := v.rules[name]
+	if !ok {
+		_, ok = v.structRules[name]
+	}
+	return ok
+}
+
+// ruleParam returns the parameter given to rule in the validator tag of field.
+func ruleParam(field reflect.StructField, rule string) (string, bool) {
+	for _, spec := range parseRules(field.Tag.Get("validator")) {
+		if spec.name == rule {
+			return spec.param, true
+		}
+	}
+	return "", false
+}
+
+// siblingString returns the string held by the field of parent with the
+// given Go or JSON name, dereferencing pointers. A nil pointer to a string
+// holds the empty string; ok is false when there is no such string field.
+func siblingString(parent reflect.Value, name string) (string, bool) {
+	if !parent.IsValid() || parent.Kind() != reflect.Struct || name == "" {
+		return "", false
+	}
+
+	f := parent.FieldByName(name)
+	if !f.IsValid() {
+		sf, ok := fieldByJSONName(parent.Type(), name)
+		if !ok {
+			return "", false
+		}
+		f = parent.FieldByIndex(sf.Index)
+	}
+	t := f.Type()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.String {
+		return "", false
+	}
+	for f.Kind() == reflect.Ptr {
+		if f.IsNil() {
+			return "", true
+		}
+		f = f.Elem()
+	}
+	return f.String(), true
+}
+
+// jsonName returns the name of field in JSON documents: the name from its
+// json tag, or the Go field name.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
 func parseParams(tag string) map[string]string {
 	params := make(map[string]string)
 	pairs := strings.Split(tag, ",")